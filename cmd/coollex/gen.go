@@ -0,0 +1,212 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex"
+	"github.com/dastoikov/cool-lex-go/v2/coollex/encoding"
+)
+
+// algorithm creates the combinations of k out of n elements, starting at rank `start`
+type algorithm func(n, k uint, start *big.Int) (coollex.Combinations, error)
+
+// advanced returns the combinations of `generator` from rank `start`, skipping ahead; it is used for the
+// algorithms without a constructor positioned at a rank
+func advanced(generator interface {
+	Combinations() coollex.Combinations
+	AdvanceBig(m *big.Int) error
+}, err error) algorithm {
+	return func(n, k uint, start *big.Int) (coollex.Combinations, error) {
+		if err != nil {
+			return nil, err
+		}
+		if err := generator.AdvanceBig(start); err != nil {
+			return nil, err
+		}
+		return generator.Combinations(), nil
+	}
+}
+
+func atComputerWord64(n, k uint, start *big.Int) (coollex.Combinations, error) {
+	word, err := coollex.NewComputerWord64(n, k)
+	if err == nil && start.Sign() > 0 {
+		word, err = coollex.NewComputerWord64At(n, k, uint(start.Uint64()))
+	}
+	return word.Combinations(), err
+}
+
+func atComputerWordBig(n, k uint, start *big.Int) (coollex.Combinations, error) {
+	word, err := coollex.NewComputerWordBig(n, k)
+	if err == nil && start.Sign() > 0 {
+		word, err = coollex.NewComputerWordBigAt(n, k, start)
+	}
+	return word.Combinations(), err
+}
+
+var algorithms = map[string]algorithm{
+	"auto": func(n, k uint, start *big.Int) (coollex.Combinations, error) {
+		if n < 64 {
+			return atComputerWord64(n, k, start)
+		}
+		return atComputerWordBig(n, k, start)
+	},
+	"cw64":  atComputerWord64,
+	"cwbig": atComputerWordBig,
+	"cw32": func(n, k uint, start *big.Int) (coollex.Combinations, error) {
+		word, err := coollex.NewComputerWord32(n, k)
+		return advanced(&word, err)(n, k, start)
+	},
+	"cw128": func(n, k uint, start *big.Int) (coollex.Combinations, error) {
+		word, err := coollex.NewComputerWordN[[2]uint64](n, k)
+		return advanced(&word, err)(n, k, start)
+	},
+	"cw256": func(n, k uint, start *big.Int) (coollex.Combinations, error) {
+		word, err := coollex.NewComputerWordN[[4]uint64](n, k)
+		return advanced(&word, err)(n, k, start)
+	},
+	"cw512": func(n, k uint, start *big.Int) (coollex.Combinations, error) {
+		word, err := coollex.NewComputerWordN[[8]uint64](n, k)
+		return advanced(&word, err)(n, k, start)
+	},
+	"linkedlist": func(n, k uint, start *big.Int) (coollex.Combinations, error) {
+		list, err := coollex.NewLinkedList(n, k)
+		return advanced(&list, err)(n, k, start)
+	},
+	"compact": func(n, k uint, start *big.Int) (coollex.Combinations, error) {
+		list, err := coollex.NewCompactLinkedList[uint64](n, k)
+		return advanced(&list, err)(n, k, start)
+	},
+	"sparse": func(n, k uint, start *big.Int) (coollex.Combinations, error) {
+		sparse, err := coollex.NewSparse(n, k)
+		return advanced(&sparse, err)(n, k, start)
+	},
+	"array": func(n, k uint, start *big.Int) (coollex.Combinations, error) {
+		array, err := coollex.NewArray(n, k)
+		return advanced(&array, err)(n, k, start)
+	},
+}
+
+const algorithmNames = "auto, cw32, cw64, cw128, cw256, cw512, cwbig, linkedlist, compact, sparse, array"
+
+// parseRank parses a non-negative decimal integer of any size
+func parseRank(name, s string) (*big.Int, error) {
+	r, ok := new(big.Int).SetString(s, 10)
+	if !ok || r.Sign() < 0 {
+		return nil, fmt.Errorf("%s: invalid non-negative integer %q", name, s)
+	}
+	return r, nil
+}
+
+func runGen(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("gen", "-n N -k K [flags]", stderr)
+	n := fs.Uint("n", 0, "number of elements to combine")
+	k := fs.Uint("k", 0, "number of elements in each combination")
+	algorithmName := fs.String("algorithm", "auto", "algorithm: "+algorithmNames+
+		";\nauto is cw64 for n<64 and cwbig otherwise")
+	startFlag := fs.String("start", "0", "rank of the first combination to write")
+	countFlag := fs.String("count", "", "number of combinations to write; all remaining, if empty")
+	endFlag := fs.String("end", "", "rank, exclusive, of the last combination to write; C(n,k), if empty")
+	formatName := fs.String("format", "indices", "output format, one of:\n"+formatHelp)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	newCombinations, ok := algorithms[*algorithmName]
+	if !ok {
+		return fmt.Errorf("unknown algorithm %q, expected one of %s", *algorithmName, algorithmNames)
+	}
+	format, err := encoding.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	if *n < *k {
+		return fmt.Errorf("n (%d) less than k (%d)", *n, *k)
+	}
+	total := new(big.Int)
+	if *k > 0 {
+		total.Binomial(int64(*n), int64(*k))
+	}
+	start, err := parseRank("start", *startFlag)
+	if err != nil {
+		return err
+	}
+	end := new(big.Int).Set(total)
+	switch {
+	case *countFlag != "" && *endFlag != "":
+		return fmt.Errorf("only one of count and end can be specified")
+	case *countFlag != "":
+		count, err := parseRank("count", *countFlag)
+		if err != nil {
+			return err
+		}
+		if end.Add(start, count); end.Cmp(total) > 0 {
+			end.Set(total)
+		}
+	case *endFlag != "":
+		if end, err = parseRank("end", *endFlag); err != nil {
+			return err
+		}
+		if end.Cmp(total) > 0 {
+			return fmt.Errorf("end rank %v out of range [0, %v]", end, total)
+		}
+	}
+	if start.Cmp(end) >= 0 {
+		if start.Cmp(total) > 0 {
+			return fmt.Errorf("start rank %v out of range [0, %v]", start, total)
+		}
+		return nil // nothing to write
+	}
+
+	combinations, err := newCombinations(*n, *k, start)
+	if err != nil {
+		return err
+	}
+	out := encoding.NewWriter(stdout, format, *n)
+	if err := generate(out, combinations, start, end); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// generate writes the combinations at ranks [start, end) to `out`; `combinations` starts at `start`
+func generate(out *encoding.Writer, combinations coollex.Combinations, start, end *big.Int) error {
+	out.SetRank(start)
+	remaining := new(big.Int).Sub(end, start)
+	limit, unlimited := remaining.Uint64(), !remaining.IsUint64()
+	for combination := range combinations {
+		if !unlimited {
+			if limit == 0 {
+				break
+			}
+			limit--
+		}
+		if err := out.WriteElements(combination); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var formatHelp = strings.Join([]string{
+	"indices: the elements, in ascending order, separated by spaces",
+	"csv:     the elements, in ascending order, separated by commas",
+	"bits:    n characters, '1' for a selected element, the element 0 first",
+	"hex:     the combination as a word, whose bit i is set for a selected element i, in hexadecimal",
+	"ndjson:  a JSON object per line, {\"rank\":R,\"elements\":[...]}",
+	"binary:  the combination as a word in little-endian byte order, in 8*ceil(n/64) bytes",
+}, "\n")
@@ -0,0 +1,143 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Command coollex generates combinations in Cool-lex order.
+
+Usage:
+
+	coollex <command> [flags]
+
+The commands are:
+
+	gen	write combinations to standard output
+	count	print the number of combinations, C(n,k)
+	rank	print the rank of a combination
+	unrank	print the combination at a rank
+	shard	print, as JSON, a plan splitting the combinations into ranges of ranks for workers
+	verify	verify that a stream of combinations is in Cool-lex order
+
+Run `coollex <command> -h` for the flags of a command.
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// command is a subcommand of the tool
+type command struct {
+	name  string
+	short string
+	run   func(args []string, stdout, stderr io.Writer) error
+}
+
+var commands = []command{
+	{"gen", "write combinations to standard output", runGen},
+	{"count", "print the number of combinations, C(n,k)", runCount},
+	{"rank", "print the rank of a combination", runRank},
+	{"unrank", "print the combination at a rank", runUnrank},
+	{"shard", "print, as JSON, a plan splitting the combinations into ranges of ranks for workers", runShard},
+	{"verify", "verify that a stream of combinations is in Cool-lex order", runVerify},
+}
+
+// errUsage reports invalid arguments; the usage has already been printed
+var errUsage = errors.New("invalid usage")
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: coollex <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", c.name, c.short)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'coollex <command> -h' for the flags of a command.")
+}
+
+// run executes the command line `args`, excluding the program name, and returns the exit code:
+// 0 on success, 1 on failure, and 2 on invalid usage.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	for _, c := range commands {
+		if c.name != args[0] {
+			continue
+		}
+		err := c.run(args[1:], stdout, stderr)
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		default:
+			fmt.Fprintf(stderr, "coollex %s: %v\n", c.name, err)
+			return 1
+		}
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stdout)
+		return 0
+	}
+	fmt.Fprintf(stderr, "coollex: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+// newFlagSet returns a flag set for the named command that reports errors to `stderr`
+func newFlagSet(name, synopsis string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("coollex "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: coollex %s %s\n\nFlags:\n", name, synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses `args`, mapping parsing errors other than help to errUsage; no arguments may follow
+// the flags
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := parseFlagsAndArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "unexpected arguments: %v\n", fs.Args())
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// parseFlagsAndArgs parses `args`, mapping parsing errors other than help to errUsage; the arguments
+// following the flags are available as `fs.Args()`
+func parseFlagsAndArgs(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
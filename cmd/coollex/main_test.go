@@ -0,0 +1,168 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// runCommand runs the command line and returns the exit code and the standard output
+func runCommand(t *testing.T, commandLine string) (int, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(strings.Fields(commandLine), &stdout, &stderr)
+	if code == 1 {
+		t.Log(stderr.String())
+	}
+	return code, stdout.String()
+}
+
+func TestGenFormats(t *testing.T) {
+	testCases := []struct {
+		args   string
+		expect string
+	}{
+		{"-n 4 -k 2", "0 1\n1 2\n0 2\n1 3\n2 3\n0 3\n"},
+		{"-n 4 -k 2 -format csv -count 2", "0,1\n1,2\n"},
+		{"-n 4 -k 2 -format bits -start 4", "0011\n1001\n"},
+		{"-n 6 -k 5 -format hex -start 1 -end 3", "3e\n3d\n"},
+		{"-n 4 -k 2 -format ndjson -end 1", "{\"rank\":0,\"elements\":[0,1]}\n"},
+		{"-n 4 -k 0", ""},
+		{"-n 4 -k 2 -start 6", ""},
+	}
+	for _, tc := range testCases {
+		code, actual := runCommand(t, "gen "+tc.args)
+		if code != 0 || actual != tc.expect {
+			t.Fatalf("gen %s: expected %q, got %q (exit code %d)", tc.args, tc.expect, actual, code)
+		}
+	}
+}
+
+func TestGenAlgorithms(t *testing.T) {
+	_, expect := runCommand(t, "gen -n 9 -k 4 -start 17 -count 50")
+	for name := range algorithms {
+		code, actual := runCommand(t, "gen -n 9 -k 4 -start 17 -count 50 -algorithm "+name)
+		if code != 0 || actual != expect {
+			t.Fatalf("algorithm %s: expected %q, got %q (exit code %d)", name, expect, actual, code)
+		}
+	}
+	// beyond 64 bits, positioned by rank
+	_, expect = runCommand(t, "gen -n 70 -k 3 -algorithm cw128 -start 54000 -count 3")
+	if code, actual := runCommand(t, "gen -n 70 -k 3 -start 54000 -count 3"); code != 0 || actual != expect {
+		t.Fatalf("expected %q, got %q (exit code %d)", expect, actual, code)
+	}
+}
+
+func TestGenErrors(t *testing.T) {
+	testCases := []struct {
+		args string
+		code int
+	}{
+		{"gen -n 2 -k 3", 1},
+		{"gen -n 4 -k 2 -start 7", 1},
+		{"gen -n 4 -k 2 -end 7", 1},
+		{"gen -n 4 -k 2 -count 1 -end 2", 1},
+		{"gen -n 4 -k 2 -algorithm none", 1},
+		{"gen -n 4 -k 2 -format none", 1},
+		{"gen -n 4 -k 2 -start -1", 1},
+		{"gen -n 64 -k 2 -algorithm cw64", 1},
+		{"gen -x", 2},
+		{"gen -n 4 -k 2 extra", 2},
+		{"none", 2},
+		{"", 2},
+		{"help", 0},
+		{"gen -h", 0},
+	}
+	for _, tc := range testCases {
+		if code, _ := runCommand(t, tc.args); code != tc.code {
+			t.Fatalf("%q: expected exit code %d, got %d", tc.args, tc.code, code)
+		}
+	}
+}
+
+func TestCountRankUnrank(t *testing.T) {
+	testCases := []struct {
+		args   string
+		expect string
+	}{
+		{"count -n 5 -k 3", "10\n"},
+		{"count -n 5 -k 0", "0\n"},
+		{"count -n 200 -k 100", "90548514656103281165404177077484163874504589675413336841320\n"},
+		{"rank -n 5 1 2 4", "4\n"},
+		{"rank -n 5 4,1,2", "4\n"},
+		{"unrank -n 5 -k 3 4", "1 2 4\n"},
+		{"unrank -n 5 -k 3 -format ndjson 9", "{\"rank\":9,\"elements\":[0,1,4]}\n"},
+	}
+	for _, tc := range testCases {
+		code, actual := runCommand(t, tc.args)
+		if code != 0 || actual != tc.expect {
+			t.Fatalf("%s: expected %q, got %q (exit code %d)", tc.args, tc.expect, actual, code)
+		}
+	}
+	// round trip beyond 64 bits
+	_, rank := runCommand(t, "rank -n 200 3 50 77 101 199")
+	if _, actual := runCommand(t, "unrank -n 200 -k 5 "+rank); actual != "3 50 77 101 199\n" {
+		t.Fatalf("unrank: expected the ranked combination, got %q for rank %s", actual, rank)
+	}
+}
+
+func TestShard(t *testing.T) {
+	code, actual := runCommand(t, "shard -n 6 -k 3 -workers 3")
+	expect := `{"n":6,"k":3,"total":20,"shards":[` +
+		`{"index":0,"start":0,"end":6,"count":6,"first":[0,1,2]},` +
+		`{"index":1,"start":6,"end":13,"count":7,"first":[1,3,4]},` +
+		`{"index":2,"start":13,"end":20,"count":7,"first":[2,3,5]}]}` + "\n"
+	if code != 0 || actual != expect {
+		t.Fatalf("expected %q, got %q (exit code %d)", expect, actual, code)
+	}
+	// the first combinations are those generated at the start ranks
+	plan, _ := planShards(30, 12, 7)
+	for _, s := range plan.Shards {
+		_, expect := runCommand(t, "gen -n 30 -k 12 -format csv -count 1 -start "+s.Start.String())
+		fields := make([]string, len(s.First))
+		for i, element := range s.First {
+			fields[i] = strconv.FormatUint(uint64(element), 10)
+		}
+		actual := strings.Join(fields, ",") + "\n"
+		if expect != actual {
+			t.Fatalf("shard %d: expected %q, got %q", s.Index, expect, actual)
+		}
+	}
+}
+
+func TestCountRankUnrankErrors(t *testing.T) {
+	testCases := []struct {
+		args string
+		code int
+	}{
+		{"count -n 2 -k 3", 1},
+		{"rank -n 5", 1},
+		{"rank -n 5 1 5", 1},
+		{"rank -n 5 1 x", 1},
+		{"rank -n 5 1 1", 1},
+		{"unrank -n 5 -k 3 10", 1},
+		{"unrank -n 5 -k 3", 2},
+		{"unrank -n 5 -k 3 -format none 1", 1},
+		{"shard -n 5 -k 3 -workers 0", 1},
+		{"shard -n 5 -k 3 -workers 11", 1},
+		{"shard -n 5 -k 3 -workers 1000000000000", 1},
+		{"shard -n 5 -k 3 extra", 2},
+	}
+	for _, tc := range testCases {
+		if code, _ := runCommand(t, tc.args); code != tc.code {
+			t.Fatalf("%q: expected exit code %d, got %d", tc.args, tc.code, code)
+		}
+	}
+}
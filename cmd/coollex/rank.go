@@ -0,0 +1,114 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex"
+	"github.com/dastoikov/cool-lex-go/v2/coollex/encoding"
+)
+
+func runCount(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("count", "-n N -k K", stderr)
+	n := fs.Uint("n", 0, "number of elements to combine")
+	k := fs.Uint("k", 0, "number of elements in each combination")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *n < *k {
+		return fmt.Errorf("n (%d) less than k (%d)", *n, *k)
+	}
+	count := new(big.Int)
+	if *k > 0 {
+		count.Binomial(int64(*n), int64(*k))
+	}
+	_, err := fmt.Fprintln(stdout, count)
+	return err
+}
+
+// parseElements parses the elements of a combination, separated by spaces (as separate arguments) or commas
+func parseElements(args []string) ([]uint, error) {
+	var elements []uint
+	for _, arg := range args {
+		for _, field := range strings.Split(arg, ",") {
+			if field == "" {
+				continue
+			}
+			element, err := strconv.ParseUint(field, 10, 0)
+			if err != nil {
+				return nil, fmt.Errorf("invalid element %q", field)
+			}
+			elements = append(elements, uint(element))
+		}
+	}
+	return elements, nil
+}
+
+func runRank(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("rank", "-n N element...", stderr)
+	n := fs.Uint("n", 0, "number of elements to combine")
+	if err := parseFlagsAndArgs(fs, args); err != nil {
+		return err
+	}
+	elements, err := parseElements(fs.Args())
+	if err != nil {
+		return err
+	}
+	// k is implied by the number of elements, which may be listed in any order
+	slices.Sort(elements)
+	rank, err := coollex.RankBig(elements, *n)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(stdout, rank)
+	return err
+}
+
+func runUnrank(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("unrank", "-n N -k K rank", stderr)
+	n := fs.Uint("n", 0, "number of elements to combine")
+	k := fs.Uint("k", 0, "number of elements in each combination")
+	formatName := fs.String("format", "indices", "output format, one of:\n"+formatHelp)
+	if err := parseFlagsAndArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "exactly one rank is expected")
+		fs.Usage()
+		return errUsage
+	}
+	format, err := encoding.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	rank, err := parseRank("rank", fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	elements, err := coollex.UnrankBig(rank, *n, *k)
+	if err != nil {
+		return err
+	}
+	out := encoding.NewWriter(stdout, format, *n)
+	out.SetRank(rank)
+	if err := out.WriteElements(slices.Values(elements)); err != nil {
+		return err
+	}
+	return out.Flush()
+}
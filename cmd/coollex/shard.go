@@ -0,0 +1,98 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex"
+)
+
+// shardPlan is the JSON output of the shard command. Ranks and counts are JSON numbers of any size.
+type shardPlan struct {
+	N      uint     `json:"n"`
+	K      uint     `json:"k"`
+	Total  *big.Int `json:"total"`
+	Shards []shard  `json:"shards"`
+}
+
+// shard is the range of ranks [Start, End) assigned to a worker. First is the combination at rank Start,
+// absent for an empty range.
+type shard struct {
+	Index uint     `json:"index"`
+	Start *big.Int `json:"start"`
+	End   *big.Int `json:"end"`
+	Count *big.Int `json:"count"`
+	First []uint   `json:"first,omitempty"`
+}
+
+// planShards splits the ranks [0, C(n,k)) into `workers` contiguous ranges whose sizes differ by at most one.
+//
+// It is an error to pass arguments such that n < k, or `workers` is 0 or more than C(n,k).
+func planShards(n, k, workers uint) (shardPlan, error) {
+	if n < k {
+		return shardPlan{}, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	if workers == 0 {
+		return shardPlan{}, fmt.Errorf("no workers")
+	}
+	total := new(big.Int)
+	if k > 0 {
+		total.Binomial(int64(n), int64(k))
+	}
+	if new(big.Int).SetUint64(uint64(workers)).Cmp(total) > 0 {
+		return shardPlan{}, fmt.Errorf("%d workers, more than the %v combinations", workers, total)
+	}
+	plan := shardPlan{N: n, K: k, Total: total, Shards: make([]shard, workers)}
+	w := new(big.Int).SetUint64(uint64(workers))
+	bound := func(i uint) *big.Int {
+		b := new(big.Int).Mul(total, new(big.Int).SetUint64(uint64(i)))
+		return b.Quo(b, w)
+	}
+	for i := range plan.Shards {
+		s := shard{Index: uint(i), Start: bound(uint(i)), End: bound(uint(i) + 1)}
+		s.Count = new(big.Int).Sub(s.End, s.Start)
+		if s.Count.Sign() > 0 {
+			first, err := coollex.UnrankBig(s.Start, n, k)
+			if err != nil {
+				return shardPlan{}, err
+			}
+			s.First = first
+		}
+		plan.Shards[i] = s
+	}
+	return plan, nil
+}
+
+func runShard(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("shard", "-n N -k K -workers W", stderr)
+	n := fs.Uint("n", 0, "number of elements to combine")
+	k := fs.Uint("k", 0, "number of elements in each combination")
+	workers := fs.Uint("workers", 1, "number of workers")
+	indent := fs.Bool("indent", false, "indent the JSON output")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	plan, err := planShards(*n, *k, *workers)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(stdout)
+	if *indent {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(plan)
+}
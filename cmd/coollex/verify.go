@@ -0,0 +1,466 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math/big"
+	"math/bits"
+	"os"
+	"slices"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex"
+	"github.com/dastoikov/cool-lex-go/v2/coollex/encoding"
+)
+
+// Verification relies on two properties of the Cool-lex order, following from its recursive definition,
+// C(s,t) = C(s-1,t)·0, rotate(C(s,t-1))·1, where position 0 is written first:
+//   - the order for n elements is a prefix of the order for any m>n elements, hence
+//   - the rank of a combination does not depend on n.
+//
+// Therefore, a stream can be verified before n is known: the oracle generates the order for a provisional n,
+// which is increased as needed; eventually, n is inferred from the greatest element of the stream.
+
+// combinationReader reads the combinations of a stream, one at a time
+type combinationReader interface {
+	// read returns the elements of the next combination, stored in `elements` if it has enough capacity,
+	// or io.EOF at the end of the stream
+	read(elements []uint) ([]uint, error)
+	// widthHint returns the number of elements that the representation of a combination allows for, if
+	// known, and 0 otherwise; `exact` reports whether it is the number of elements to combine, n
+	widthHint() (width uint, exact bool)
+}
+
+// lineReader reads the combinations of a stream of text, one per line, skipping empty lines
+type lineReader struct {
+	scanner *bufio.Scanner
+	line    uint
+	width   uint
+	exact   bool
+	parse   func(r *lineReader, line []byte, elements []uint) ([]uint, error)
+}
+
+func newLineReader(in io.Reader, parse func(*lineReader, []byte, []uint) ([]uint, error)) *lineReader {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64<<10), 64<<20)
+	return &lineReader{scanner: scanner, parse: parse}
+}
+
+func (r *lineReader) read(elements []uint) ([]uint, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		elements, err := r.parse(r, line, elements[:0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		return elements, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (r *lineReader) widthHint() (uint, bool) {
+	return r.width, r.exact
+}
+
+// parseIndices parses elements separated by spaces, tabs or commas
+func parseIndices(_ *lineReader, line []byte, elements []uint) ([]uint, error) {
+	for i := 0; i < len(line); {
+		if c := line[i]; c == ' ' || c == ',' || c == '\t' {
+			i++
+			continue
+		}
+		element, start := uint64(0), i
+		for ; i < len(line) && '0' <= line[i] && line[i] <= '9'; i++ {
+			hi, lo := bits.Mul64(element, 10)
+			sum, carry := bits.Add64(lo, uint64(line[i]-'0'), 0)
+			if hi|carry != 0 || uint64(uint(sum)) != sum {
+				return nil, fmt.Errorf("element %q out of range", line[start:i+1])
+			}
+			element = sum
+		}
+		if i == start || (i < len(line) && line[i] != ' ' && line[i] != ',' && line[i] != '\t') {
+			return nil, fmt.Errorf("invalid element in %q", line)
+		}
+		elements = append(elements, uint(element))
+	}
+	return elements, nil
+}
+
+// parseBits parses a bitstring, the element 0 first
+func parseBits(r *lineReader, line []byte, elements []uint) ([]uint, error) {
+	if r.width == 0 {
+		r.width, r.exact = uint(len(line)), true
+	} else if uint(len(line)) != r.width {
+		return nil, fmt.Errorf("bitstring of %d bits, expected %d", len(line), r.width)
+	}
+	for i, c := range line {
+		switch c {
+		case '1':
+			elements = append(elements, uint(i))
+		case '0':
+		default:
+			return nil, fmt.Errorf("invalid bitstring %q", line)
+		}
+	}
+	return elements, nil
+}
+
+// parseHex parses a word in hexadecimal
+func parseHex(r *lineReader, line []byte, elements []uint) ([]uint, error) {
+	word, ok := new(big.Int).SetString(string(line), 16)
+	if !ok || word.Sign() < 0 {
+		return nil, fmt.Errorf("invalid hexadecimal word %q", line)
+	}
+	r.width = max(r.width, uint(len(line))*4)
+	return appendWordElements(elements, word.Bits()), nil
+}
+
+// parseNDJSON parses the elements of a JSON object, as written by gen
+func parseNDJSON(_ *lineReader, line []byte, elements []uint) ([]uint, error) {
+	var object struct {
+		Elements []uint `json:"elements"`
+	}
+	if err := json.Unmarshal(line, &object); err != nil {
+		return nil, err
+	}
+	return append(elements, object.Elements...), nil
+}
+
+// appendWordElements appends the positions of the bits set in `words`, the least-significant word first
+func appendWordElements(elements []uint, words []big.Word) []uint {
+	for i, w := range words {
+		for ; w != 0; w &= w - 1 {
+			elements = append(elements, uint(i*bits.UintSize+bits.TrailingZeros(uint(w))))
+		}
+	}
+	return elements
+}
+
+// binaryReader reads the combinations of a stream of little-endian words of `width` bytes each
+type binaryReader struct {
+	in    *bufio.Reader
+	word  []byte
+	width uint
+}
+
+func (r *binaryReader) read(elements []uint) ([]uint, error) {
+	if _, err := io.ReadFull(r.in, r.word); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("incomplete word at the end of the stream")
+		}
+		return nil, err
+	}
+	elements = elements[:0]
+	for i, b := range r.word {
+		for ; b != 0; b &= b - 1 {
+			elements = append(elements, uint(i*8+bits.TrailingZeros8(b)))
+		}
+	}
+	return elements, nil
+}
+
+func (r *binaryReader) widthHint() (uint, bool) {
+	return r.width * 8, false
+}
+
+// oracle generates the expected combinations
+type oracle struct {
+	n, k uint
+	next func() (coollex.Elements, bool)
+	stop func()
+}
+
+// seek positions the oracle at `rank`, for provisional n
+func (o *oracle) seek(rank *big.Int) {
+	if o.stop != nil {
+		o.stop()
+	}
+	word, err := coollex.NewComputerWordBigAt(o.n, o.k, rank)
+	if err != nil {
+		// beyond the last combination
+		o.next, o.stop = func() (coollex.Elements, bool) { return nil, false }, func() {}
+		return
+	}
+	o.next, o.stop = iter.Pull(word.Combinations())
+}
+
+// rankRange is a range of ranks [start, end) found consecutively in the stream
+type rankRange struct {
+	start, end *big.Int
+}
+
+// verification is the outcome of verifying a stream
+type verification struct {
+	n, k       uint
+	nInferred  bool
+	count      uint64
+	malformed  uint64 // of the combinations counted, those not of k out of n elements
+	total      *big.Int
+	distinct   *big.Int
+	missing    *big.Int
+	duplicated *big.Int
+	deviation  string // the first deviation from the order, if any
+}
+
+func (v *verification) ok() bool {
+	return v.deviation == "" && v.malformed == 0 && v.missing.Sign() == 0 && v.duplicated.Sign() == 0
+}
+
+func (v *verification) report(w io.Writer) error {
+	inferred := ""
+	if v.nInferred {
+		inferred = " (inferred)"
+	}
+	result := "OK"
+	if !v.ok() {
+		result = "FAILED"
+	}
+	_, err := fmt.Fprintf(w, "n: %d%s\nk: %d\ncombinations: %d\nmalformed: %d\nC(n,k): %v\ndistinct: %v\nmissing: %v\nduplicated: %v\n",
+		v.n, inferred, v.k, v.count, v.malformed, v.total, v.distinct, v.missing, v.duplicated)
+	if err == nil && v.deviation != "" {
+		_, err = fmt.Fprintf(w, "first deviation: %s\n", v.deviation)
+	}
+	if err == nil {
+		_, err = fmt.Fprintf(w, "result: %s\n", result)
+	}
+	return err
+}
+
+// verifyStream verifies that `in` yields the combinations of k out of n elements in Cool-lex order; n and k
+// are inferred if 0.
+func verifyStream(in combinationReader, n, k uint) (verification, error) {
+	v := verification{n: n, k: k, nInferred: n == 0}
+	var (
+		actual, expect []uint
+		maxElement     uint
+		runs           []rankRange
+		run            = rankRange{start: new(big.Int)}
+		runLength      uint64 // in the current run
+		o              oracle
+	)
+	closeRun := func() {
+		run.end = new(big.Int).Add(run.start, new(big.Int).SetUint64(runLength))
+		runs = append(runs, run)
+	}
+	for {
+		var err error
+		if actual, err = in.read(actual); err == io.EOF {
+			break
+		} else if err != nil {
+			return v, err
+		}
+		position := v.count
+		if v.k == 0 {
+			v.k = uint(len(actual)) // 0 until a combination of any elements
+		}
+		if reason := malformed(actual, n, v.k); reason != "" {
+			// a deviation that covers no rank; the next combination is still expected
+			v.count++
+			v.malformed++
+			if v.deviation == "" {
+				v.deviation = fmt.Sprintf("combination %d: %s", position, reason)
+			}
+			continue
+		}
+		maxElement = max(maxElement, actual[len(actual)-1])
+
+		if o.next == nil {
+			o.n, o.k = n, v.k
+			if n == 0 {
+				width, exact := in.widthHint()
+				if exact {
+					// the width of a bitstring
+					n, v.n, o.n = width, width, width
+				} else {
+					o.n = max(2*v.k, width, maxElement+1)
+				}
+			}
+			o.seek(run.start)
+		}
+		// the expected combination, at rank run.start+runLength
+		expect = expect[:0]
+		for {
+			elements, ok := o.next()
+			if ok {
+				expect = slices.AppendSeq(expect, elements)
+				break
+			}
+			if n > 0 {
+				break // beyond the last combination
+			}
+			// beyond the last combination for provisional n
+			o.n *= 2
+			o.seek(new(big.Int).Add(run.start, new(big.Int).SetUint64(runLength)))
+		}
+		v.count++
+		if slices.Equal(expect, actual) {
+			runLength++
+			continue
+		}
+
+		// deviation: start another run at the rank of the actual combination
+		expectRank := new(big.Int).Add(run.start, new(big.Int).SetUint64(runLength))
+		actualRank, err := coollex.RankBig(actual, maxElement+1)
+		if err != nil {
+			return v, fmt.Errorf("combination %d: %w", position, err)
+		}
+		if v.deviation == "" {
+			expected := "none, after the last combination"
+			if len(expect) > 0 {
+				expected = fmt.Sprint(expect)
+			}
+			v.deviation = fmt.Sprintf("combination %d: expected %s, got %v", position, expected, actual)
+			if len(expect) > 0 {
+				switch diff := new(big.Int).Sub(actualRank, expectRank); diff.Sign() {
+				case 1:
+					v.deviation += fmt.Sprintf(", skipping %v combinations", diff)
+				case -1:
+					v.deviation += fmt.Sprintf(", repeating the combination at rank %v", actualRank)
+				}
+			}
+		}
+		closeRun()
+		run, runLength = rankRange{start: actualRank}, 1
+		o.n = max(o.n, maxElement+1)
+		o.seek(actualRank)
+		o.next() // the actual combination
+	}
+	if o.stop != nil {
+		o.stop()
+	}
+	closeRun()
+
+	if n == 0 {
+		if v.count > v.malformed {
+			v.n = maxElement + 1
+		}
+	}
+	v.total = new(big.Int)
+	if v.k > 0 {
+		v.total.Binomial(int64(v.n), int64(v.k))
+	}
+	// the union of the runs
+	slices.SortFunc(runs, func(a, b rankRange) int { return a.start.Cmp(b.start) })
+	v.distinct = new(big.Int)
+	covered := new(big.Int)
+	for _, r := range runs {
+		start := r.start
+		if start.Cmp(covered) < 0 {
+			start = covered
+		}
+		if r.end.Cmp(start) > 0 {
+			v.distinct.Add(v.distinct, new(big.Int).Sub(r.end, start))
+			covered = r.end
+		}
+	}
+	v.missing = new(big.Int).Sub(v.total, v.distinct)
+	v.duplicated = new(big.Int).Sub(new(big.Int).SetUint64(v.count-v.malformed), v.distinct)
+	return v, nil
+}
+
+// malformed returns why the elements are not a combination of k out of n elements, or "" if they are; any n
+// for n=0
+func malformed(elements []uint, n, k uint) string {
+	if len(elements) == 0 {
+		return "no elements"
+	}
+	if uint(len(elements)) != k {
+		return fmt.Sprintf("%d elements %v, expected k=%d", len(elements), elements, k)
+	}
+	for i, element := range elements {
+		if i > 0 && element <= elements[i-1] {
+			return fmt.Sprintf("elements %v not in ascending order", elements)
+		}
+		if n > 0 && element >= n {
+			return fmt.Sprintf("element %d out of range [0, %d)", element, n)
+		}
+	}
+	return ""
+}
+
+// errVerification reports a stream that is not in Cool-lex order; the report has already been printed
+var errVerification = errors.New("the stream is not in Cool-lex order")
+
+func runVerify(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("verify", "[-n N] [-k K] [-format F] [file]", stderr)
+	n := fs.Uint("n", 0, "number of elements to combine; inferred from the greatest element, if 0")
+	k := fs.Uint("k", 0, "number of elements in each combination; inferred from the first combination, if 0")
+	formatName := fs.String("format", "indices", "input format: indices (also csv), bits, hex, ndjson, as written by gen,\n"+
+		"or binary, for little-endian words of -width bytes")
+	width := fs.Uint("width", 0, "width of the binary words in bytes; 8*ceil(n/64) if 0 and n is specified, 8 otherwise")
+	if err := parseFlagsAndArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fmt.Fprintln(stderr, "at most one file is expected")
+		fs.Usage()
+		return errUsage
+	}
+	if *n > 0 && *n < *k {
+		return fmt.Errorf("n (%d) less than k (%d)", *n, *k)
+	}
+	var in io.Reader = os.Stdin
+	if fs.NArg() == 1 && fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var reader combinationReader
+	switch *formatName {
+	case "indices", "csv":
+		reader = newLineReader(in, parseIndices)
+	case "bits":
+		reader = newLineReader(in, parseBits)
+	case "hex":
+		reader = newLineReader(in, parseHex)
+	case "ndjson":
+		reader = newLineReader(in, parseNDJSON)
+	case "binary":
+		w := *width
+		if w == 0 {
+			w = uint(encoding.WordWidth(*n))
+		}
+		reader = &binaryReader{in: bufio.NewReaderSize(in, 64<<10), word: make([]byte, w), width: w}
+	default:
+		return fmt.Errorf("unknown format %q, expected one of indices, csv, bits, hex, ndjson, binary", *formatName)
+	}
+
+	v, err := verifyStream(reader, *n, *k)
+	if err != nil {
+		return err
+	}
+	if err := v.report(stdout); err != nil {
+		return err
+	}
+	if !v.ok() {
+		return errVerification
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex"
+)
+
+// verifyText verifies the lines in the format
+func verifyText(t *testing.T, lines []string, format string, n, k uint) verification {
+	t.Helper()
+	var reader combinationReader
+	in := strings.NewReader(strings.Join(lines, "\n"))
+	switch format {
+	case "bits":
+		reader = newLineReader(in, parseBits)
+	case "hex":
+		reader = newLineReader(in, parseHex)
+	case "ndjson":
+		reader = newLineReader(in, parseNDJSON)
+	default:
+		reader = newLineReader(in, parseIndices)
+	}
+	v, err := verifyStream(reader, n, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// genLines returns the lines written by gen
+func genLines(t *testing.T, args string) []string {
+	t.Helper()
+	code, out := runCommand(t, "gen "+args)
+	if code != 0 {
+		t.Fatalf("gen %s: exit code %d", args, code)
+	}
+	return strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+}
+
+func TestVerifyInOrder(t *testing.T) {
+	for _, format := range []string{"indices", "csv", "bits", "hex", "ndjson"} {
+		for _, tc := range []struct{ n, k uint }{{1, 1}, {8, 3}, {9, 9}, {70, 2}, {130, 2}} {
+			lines := genLines(t, fmt.Sprintf("-n %d -k %d -format %s", tc.n, tc.k, format))
+			for _, given := range []struct{ n, k uint }{{0, 0}, tc} {
+				v := verifyText(t, lines, format, given.n, given.k)
+				if !v.ok() || v.n != tc.n || v.k != tc.k || v.count != uint64(len(lines)) {
+					t.Fatalf("format %s, n %d, k %d: unexpected %+v", format, tc.n, tc.k, v)
+				}
+			}
+		}
+	}
+}
+
+func TestVerifyDeviations(t *testing.T) {
+	lines := genLines(t, "-n 8 -k 3")
+
+	skipped := append(append([]string{}, lines[:4]...), lines[6:]...)
+	v := verifyText(t, skipped, "indices", 0, 0)
+	if v.ok() || v.missing.Int64() != 2 || v.duplicated.Int64() != 0 || !strings.Contains(v.deviation, "combination 4:") ||
+		!strings.Contains(v.deviation, "skipping 2 combinations") {
+		t.Fatalf("skipped combinations: unexpected %+v", v)
+	}
+
+	repeated := append(append(append([]string{}, lines[:10]...), lines[3:5]...), lines[10:]...)
+	v = verifyText(t, repeated, "indices", 0, 0)
+	if v.ok() || v.missing.Int64() != 0 || v.duplicated.Int64() != 2 || !strings.Contains(v.deviation, "repeating the combination at rank 3") {
+		t.Fatalf("repeated combinations: unexpected %+v", v)
+	}
+
+	// truncated: n is inferred from the greatest element, unless the stream is of bitstrings
+	v = verifyText(t, lines[:35], "indices", 0, 0) // C(7,3)
+	if !v.ok() || v.n != 7 {
+		t.Fatalf("truncated: unexpected %+v", v)
+	}
+	v = verifyText(t, lines[:35], "indices", 8, 3)
+	if v.ok() || v.missing.Int64() != 21 || v.deviation != "" {
+		t.Fatalf("truncated: unexpected %+v", v)
+	}
+	v = verifyText(t, genLines(t, "-n 8 -k 3 -format bits -count 30"), "bits", 0, 0)
+	if v.ok() || v.n != 8 || v.missing.Int64() != 26 {
+		t.Fatalf("truncated bitstrings: unexpected %+v", v)
+	}
+
+	extra := append(append([]string{}, lines...), lines[0])
+	v = verifyText(t, extra, "indices", 8, 3)
+	if v.ok() || v.duplicated.Int64() != 1 || !strings.Contains(v.deviation, "after the last combination") {
+		t.Fatalf("extra combination: unexpected %+v", v)
+	}
+
+	misplacedFirst := append([]string{lines[1]}, lines[2:]...)
+	v = verifyText(t, misplacedFirst, "indices", 0, 0)
+	if v.ok() || v.missing.Int64() != 1 || !strings.Contains(v.deviation, "combination 0:") {
+		t.Fatalf("missing first combination: unexpected %+v", v)
+	}
+}
+
+func TestVerifyBinary(t *testing.T) {
+	word, _ := coollex.NewComputerWord64(12, 5)
+	var words []byte
+	for w := range word.Words() {
+		words = binary.LittleEndian.AppendUint64(words, uint64(w))
+	}
+	file := filepath.Join(t.TempDir(), "words")
+	if err := os.WriteFile(file, words, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if code, out := runCommand(t, "verify -format binary "+file); code != 0 || !strings.Contains(out, "n: 12 (inferred)") {
+		t.Fatalf("unexpected report %q (exit code %d)", out, code)
+	}
+	if code, out := runCommand(t, "verify -format binary -n 12 -k 5 "+file); code != 0 || !strings.Contains(out, "result: OK") {
+		t.Fatalf("unexpected report %q (exit code %d)", out, code)
+	}
+	// words of 2 bytes
+	var narrow []byte
+	for i := 0; i < len(words); i += 8 {
+		narrow = append(narrow, words[i:i+2]...)
+	}
+	if err := os.WriteFile(file, narrow, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if code, out := runCommand(t, "verify -format binary -width 2 "+file); code != 0 || !strings.Contains(out, "combinations: 792") {
+		t.Fatalf("unexpected report %q (exit code %d)", out, code)
+	}
+	if err := os.WriteFile(file, words[:len(words)-3], 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if code, _ := runCommand(t, "verify -format binary "+file); code != 1 {
+		t.Fatalf("exit code 1 is expected for an incomplete word, got %d", code)
+	}
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	lines := genLines(t, "-n 6 -k 2")
+	for _, tc := range []struct {
+		line, deviation string
+		n               uint
+	}{
+		{"2 1", "not in ascending order", 0},
+		{"3 3", "not in ascending order", 0},
+		{"0 1 2", "3 elements [0 1 2], expected k=2", 0},
+		{"0 6", "element 6 out of range [0, 6)", 6},
+	} {
+		// malformed combinations count as deviations, not as combinations of any rank
+		malformed := append(append(append([]string{}, lines[:4]...), tc.line), lines[4:]...)
+		v := verifyText(t, malformed, "indices", tc.n, 0)
+		if v.ok() || v.malformed != 1 || v.count != uint64(len(lines)+1) || v.missing.Sign() != 0 || v.duplicated.Sign() != 0 ||
+			!strings.Contains(v.deviation, "combination 4: ") || !strings.Contains(v.deviation, tc.deviation) {
+			t.Fatalf("%q: unexpected %+v", tc.line, v)
+		}
+	}
+
+	// counting continues past malformed combinations
+	skipped := append(append([]string{"1 0"}, lines[:4]...), lines[6:]...)
+	v := verifyText(t, skipped, "indices", 0, 0)
+	if v.ok() || v.malformed != 1 || v.missing.Int64() != 2 || !strings.Contains(v.deviation, "combination 0: ") {
+		t.Fatalf("skipped combinations: unexpected %+v", v)
+	}
+}
+
+func TestVerifyErrors(t *testing.T) {
+	testCases := []struct {
+		lines  []string
+		n, k   uint
+		format string
+	}{
+		{[]string{"0 x"}, 0, 0, "indices"},
+		{[]string{"0 99999999999999999999999"}, 0, 0, "indices"},
+		{[]string{"110", "1010"}, 0, 0, "bits"},
+		{[]string{"1x0"}, 0, 0, "bits"},
+		{[]string{"xyz"}, 0, 0, "hex"},
+		{[]string{"{"}, 0, 0, "ndjson"},
+	}
+	for _, tc := range testCases {
+		reader := newLineReader(strings.NewReader(strings.Join(tc.lines, "\n")), map[string]func(*lineReader, []byte, []uint) ([]uint, error){
+			"indices": parseIndices, "bits": parseBits, "hex": parseHex, "ndjson": parseNDJSON,
+		}[tc.format])
+		if _, err := verifyStream(reader, tc.n, tc.k); err == nil {
+			t.Fatalf("error is expected for %q", tc.lines)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// Skipping ahead ranks the current combination, adds the distance, and repositions the generator at the
+// combination of the resulting rank, in O(n) arithmetic operations, regardless of the distance. Short distances
+// are stepped instead.
+
+// maxSteps is the greatest distance that is stepped rather than ranked
+const maxSteps = 64
+
+// repositionable is implemented by the generators that skip ahead
+type repositionable interface {
+	// current reports whether the generator is positioned at a combination yet to be yielded
+	current() bool
+	// step advances to the next combination, or past the last one
+	step()
+	// size returns n
+	size() uint
+	// reposition positions the generator at the combination of the elements, in ascending order
+	reposition(elements []uint)
+	// end positions the generator past the last combination
+	end()
+	Elements() Elements
+}
+
+// firstOfRise returns the position `x` of the array-based and the linked-list algorithms for the combination
+// of the elements: the first selected element after the leading selected elements; or the last leading one,
+// if all the selected elements are leading, as for the first combination
+func firstOfRise(elements []uint) uint {
+	y := uint(0)
+	for y < uint(len(elements)) && elements[y] == y {
+		y++
+	}
+	if y == uint(len(elements)) {
+		return y - 1
+	}
+	return elements[y]
+}
+
+// advance skips `m` combinations, see ComputerWord64.Advance
+func advance(g repositionable, m uint64) {
+	if m <= maxSteps {
+		for ; m > 0 && g.current(); m-- {
+			g.step()
+		}
+		return
+	}
+	if stop, wrap := limitsOf(g); stop != nil || wrap {
+		advanceLimited(g, new(big.Int).SetUint64(m))
+		return
+	}
+	if !g.current() {
+		return
+	}
+	n, elements := g.size(), slices.Collect(g.Elements())
+	k := uint(len(elements))
+	count, err := numComb(n, k)
+	if err != nil {
+		advanceRanked(g, n, elements, new(big.Int).SetUint64(m))
+		return
+	}
+	rank, _ := Rank(elements, n) // no error for a combination of a generator
+	if m >= uint64(count-rank) {
+		g.end()
+		return
+	}
+	elements, _ = unrank(rank+uint(m), n, k, elements) // no error for a rank in range
+	g.reposition(elements)
+}
+
+// advanceBig skips `m` combinations, see ComputerWord64.AdvanceBig
+func advanceBig(g repositionable, m *big.Int) error {
+	if m.Sign() < 0 {
+		return fmt.Errorf("negative distance %v", m)
+	}
+	if m.IsUint64() {
+		advance(g, m.Uint64())
+		return nil
+	}
+	if stop, wrap := limitsOf(g); stop != nil || wrap {
+		advanceLimited(g, m)
+		return nil
+	}
+	if g.current() {
+		advanceRanked(g, g.size(), slices.Collect(g.Elements()), m)
+	}
+	return nil
+}
+
+// advanceRanked skips `m` combinations after the current one, of the elements, with ranks of any size
+func advanceRanked(g repositionable, n uint, elements []uint, m *big.Int) {
+	rank, _ := RankBig(elements, n) // no error for a combination of a generator
+	rank.Add(rank, m)
+	if rank.Cmp(binomial(n, uint(len(elements)))) >= 0 {
+		g.end()
+		return
+	}
+	elements, _ = UnrankBig(rank, n, uint(len(elements))) // no error for a rank in range
+	g.reposition(elements)
+}
+
+// advanceLimited skips `m` combinations of a generator with limits, see limited
+func advanceLimited(g repositionable, m *big.Int) {
+	if m.Cmp(remaining(g)) >= 0 {
+		g.end()
+		return
+	}
+	n, elements := g.size(), slices.Collect(g.Elements())
+	k := uint(len(elements))
+	rank, _ := RankBig(elements, n) // no error for a combination of a generator
+	rank.Add(rank, m)
+	if count := binomial(n, k); rank.Cmp(count) >= 0 {
+		rank.Sub(rank, count) // wraps around past the last combination, as m < count
+		g.(limited).wrapped()
+	}
+	elements, _ = UnrankBig(rank, n, k) // no error for a rank in range
+	g.reposition(elements)
+}
+
+// stride returns an iterator over every m-th combination, see ComputerWord64.Stride
+func stride(g repositionable, m uint64) Combinations {
+	m = max(m, 1)
+	return func(yield func(Elements) bool) {
+		for g.current() && yield(g.Elements()) {
+			advance(g, m)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+type skipper interface {
+	coollexAlgorithm
+	Advance(m uint64)
+	AdvanceBig(m *big.Int) error
+	Stride(m uint64) Combinations
+}
+
+var skippers = map[string]func(n, k uint) (skipper, error){
+	"ComputerWord32": func(n, k uint) (skipper, error) { g, err := NewComputerWord32(n, k); return &g, err },
+	"ComputerWord64": func(n, k uint) (skipper, error) { g, err := NewComputerWord64(n, k); return &g, err },
+	"ComputerWord128": func(n, k uint) (skipper, error) {
+		g, err := NewComputerWordN[[2]uint64](n, k)
+		return &g, err
+	},
+	"ComputerWordBig": func(n, k uint) (skipper, error) { g, err := NewComputerWordBig(n, k); return &g, err },
+	"LinkedList":      func(n, k uint) (skipper, error) { g, err := NewLinkedList(n, k); return &g, err },
+	"CompactLinkedList": func(n, k uint) (skipper, error) {
+		g, err := NewCompactLinkedList[uint32](n, k)
+		return &g, err
+	},
+	"Array":  func(n, k uint) (skipper, error) { g, err := NewArray(n, k); return &g, err },
+	"Sparse": func(n, k uint) (skipper, error) { g, err := NewSparse(n, k); return &g, err },
+}
+
+// collect returns the remaining combinations of the generator
+func collect(combinations Combinations) [][]uint {
+	var all [][]uint
+	for combination := range combinations {
+		all = append(all, slices.Collect(combination))
+	}
+	return all
+}
+
+func TestStride(t *testing.T) {
+	for name, newSkipper := range skippers {
+		for _, tc := range []struct{ n, k uint }{{1, 1}, {5, 5}, {9, 1}, {12, 5}, {14, 7}, {20, 3}} {
+			g, _ := newSkipper(tc.n, tc.k)
+			all := collect(g.Combinations())
+			for _, m := range []uint64{0, 1, 2, 7, 64, 65, 100, 1000} {
+				var expect [][]uint
+				for i := 0; i < len(all); i += int(max(m, 1)) {
+					expect = append(expect, all[i])
+				}
+				g, _ := newSkipper(tc.n, tc.k)
+				if actual := collect(g.Stride(m)); !slices.EqualFunc(actual, expect, slices.Equal) {
+					t.Fatalf("%s, n=%d, k=%d, m=%d: expected %v, got %v", name, tc.n, tc.k, m, expect, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	const n, k = 13, 6
+	for name, newSkipper := range skippers {
+		g, _ := newSkipper(n, k)
+		all := collect(g.Combinations())
+		for _, start := range []int{0, 1, 70, 500} {
+			for _, m := range []uint64{0, 3, 64, 65, 900, uint64(len(all) - start - 1), uint64(len(all) - start)} {
+				g, _ := newSkipper(n, k)
+				// stops at the combination at `start`, for which yield returned false
+				i := 0
+				for range g.Combinations() {
+					if i == start {
+						break
+					}
+					i++
+				}
+				g.Advance(m)
+				expect := all[min(start+int(m), len(all)):]
+				if actual := collect(g.Combinations()); !slices.EqualFunc(actual, expect, slices.Equal) {
+					t.Fatalf("%s: from %d, advanced by %d: expected %d combinations, got %d",
+						name, start, m, len(expect), len(actual))
+				}
+				// ended
+				g.Advance(m + 1000)
+				if len(collect(g.Combinations())) != 0 {
+					t.Fatalf("%s: expected no combinations once ended", name)
+				}
+			}
+		}
+	}
+}
+
+func TestAdvanceBig(t *testing.T) {
+	const n, k = 200, 100
+	rank, _ := new(big.Int).SetString("12345678901234567890123456789", 10)
+	expect, _ := UnrankBig(rank, n, k)
+	for _, name := range []string{"ComputerWordBig", "LinkedList", "CompactLinkedList", "Sparse", "Array"} {
+		g, _ := skippers[name](n, k)
+		if err := g.AdvanceBig(rank); err != nil {
+			t.Fatal(err)
+		}
+		for combination := range g.Combinations() {
+			if actual := slices.Collect(combination); !slices.Equal(actual, expect) {
+				t.Fatalf("%s: expected %v, got %v", name, expect, actual)
+			}
+			break
+		}
+		g.Advance(1 << 40)
+		if err := g.AdvanceBig(binomial(n, k)); err != nil {
+			t.Fatal(err)
+		}
+		if len(collect(g.Combinations())) != 0 {
+			t.Fatalf("%s: expected no combinations once ended", name)
+		}
+		if err := g.AdvanceBig(big.NewInt(-1)); err == nil {
+			t.Fatalf("%s: error is expected for a negative distance", name)
+		}
+	}
+}
+
+func TestAdvanceNoCombinations(t *testing.T) {
+	for name, newSkipper := range skippers {
+		g, _ := newSkipper(5, 0)
+		g.Advance(1000)
+		if len(collect(g.Stride(2))) != 0 {
+			t.Fatalf("%s: expected no combinations for k=0", name)
+		}
+	}
+}
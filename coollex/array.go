@@ -0,0 +1,167 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"fmt"
+	"iter"
+	"math/big"
+)
+
+// Array implements the array-based iterative algorithm from the paper, see section 3.2. Iterative Algorithms,
+// page 8. The combination is stored in an array of n values; each successor is computed looplessly, updating
+// at most four array entries.
+type Array struct {
+	// b, x, y are named as found in the research paper, with 0-based indices
+	// b - the combination; true values denote the selected elements
+	// x - the first index, after the first false value, whose value is true; len(b) once ended
+	// y - the number of leading true values
+	// initially, with no false value among the leading values, x=y=k-1
+	b    []bool
+	x, y uint
+}
+
+// hasNext reports whether more combinations are available
+func (array *Array) hasNext() bool {
+	return array.x < uint(len(array.b))
+}
+
+// next advances to the next combination in cool-lex order
+func (array *Array) next() {
+	b, x, y := array.b, array.x, array.y
+	if x+1 == uint(len(b)) {
+		array.x = x + 1
+		return
+	}
+	b[x] = false
+	b[y] = true
+	x++
+	y++
+	if !b[x] {
+		b[x] = true
+		b[0] = false
+		if y > 1 {
+			x = 1
+		}
+		y = 0
+	}
+	array.x, array.y = x, y
+}
+
+// newArray initializes the algorithm for the specified number of 0-bits (s) and number of 1-bits (t).
+// Precondition: `t>0`.
+func newArray(s, t uint) Array {
+	b := make([]bool, s+t)
+	for i := range t {
+		b[i] = true
+	}
+	return Array{b: b, x: t - 1, y: t - 1}
+}
+
+// Elements returns an iterator over the elements selected for the current combination.
+func (array *Array) Elements() Elements {
+	return func(yield func(uint) bool) {
+		for i, selected := range array.b {
+			if selected && !yield(uint(i)) {
+				break
+			}
+		}
+	}
+}
+
+// Combinations returns an iterator over the generated combinations.
+func (array *Array) Combinations() Combinations {
+	return func(yield func(Elements) bool) {
+		for array.hasNext() && yield(array.Elements()) {
+			array.next()
+		}
+	}
+}
+
+// Bits returns an iterator over the generated combinations as follows:
+//   - a combination is represented by a slice of `n` values
+//   - values that are true represent the elements selected for the combination
+//
+// Note: Bits provides raw access to the internal state of the algorithm and should only be
+// used for reading.
+func (array *Array) Bits() iter.Seq[[]bool] {
+	return func(yield func([]bool) bool) {
+		for array.hasNext() && yield(array.b) {
+			array.next()
+		}
+	}
+}
+
+// NewArray returns a combinations generator that yields combinations in Cool-lex order, implementing internally
+// the array-based iterative Cool-lex algorithm.
+//
+// n: number of elements to combine; n>=k must hold.
+//
+// k: number of elements in each combination.
+//
+// It is an error to pass arguments such that n < k.
+func NewArray(n, k uint) (Array, error) {
+	if n < k {
+		return Array{}, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	if k == 0 {
+		return Array{}, nil
+	}
+	return newArray(n-k, k), nil
+}
+
+func (array *Array) current() bool {
+	return array.hasNext()
+}
+
+func (array *Array) step() {
+	array.next()
+}
+
+func (array *Array) size() uint {
+	return uint(len(array.b))
+}
+
+func (array *Array) reposition(elements []uint) {
+	b := array.b
+	clear(b)
+	for _, element := range elements {
+		b[element] = true
+	}
+	y := uint(0) // the number of leading true values, see newArray for the first combination
+	for y < uint(len(elements))-1 && elements[y] == y {
+		y++
+	}
+	array.x, array.y = firstOfRise(elements), y
+}
+
+func (array *Array) end() {
+	array.x = uint(len(array.b))
+}
+
+// Advance skips `m` combinations, see ComputerWord64.Advance.
+func (array *Array) Advance(m uint64) {
+	advance(array, m)
+}
+
+// AdvanceBig skips `m` combinations, see ComputerWord64.Advance.
+//
+// It is an error to pass a negative `m`.
+func (array *Array) AdvanceBig(m *big.Int) error {
+	return advanceBig(array, m)
+}
+
+// Stride returns an iterator over every m-th generated combination, see ComputerWord64.Stride.
+func (array *Array) Stride(m uint64) Combinations {
+	return stride(array, m)
+}
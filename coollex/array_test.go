@@ -0,0 +1,49 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import "testing"
+
+func BenchmarkArray(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		array, _ := NewArray(benchAlgorithmN, benchAlgorithmK)
+		for array.hasNext() {
+			array.next()
+		}
+	}
+}
+
+func TestArray(t *testing.T) {
+	testCoollex(t, func(n, k uint) (coollexAlgorithm, error) {
+		array, err := NewArray(n, k)
+		return &array, err
+	})
+	if _, err := NewArray(2, 3); err == nil {
+		t.Fatal("error is expected for n<k")
+	}
+}
+
+// TestArrayBits verifies the order of the combinations as yielded by Bits.
+func TestArrayBits(t *testing.T) {
+	testOrder(t, func(n, k uint) (coollexAlgorithm, error) {
+		array, err := NewArray(n, k)
+		return wordGenerator[[]bool]{array.Bits(), func(bits []bool) Elements {
+			return func(yield func(uint) bool) {
+				for i, selected := range bits {
+					if selected && !yield(uint(i)) {
+						return
+					}
+				}
+			}
+		}}, err
+	}, largeCases...)
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"math/big"
+	"math/bits"
+	"slices"
+	"strconv"
+)
+
+// Combination is an immutable combination, detached from the state of any generator. Unlike an `Elements`
+// iterator, which reads the current combination of a generator, a Combination remains valid once the generator
+// advances.
+//
+// Combinations of the same elements are equal, also by the `==` operator; thus, a Combination can be used as a
+// map key. The zero value is the combination of no elements.
+type Combination struct {
+	// the elements, in ascending order: the first one, then the gap to each following one (the difference less
+	// one), each as a uvarint
+	elements string
+}
+
+// newCombination returns the combination of the elements.
+// Precondition: `elements` in strictly ascending order.
+func newCombination(elements []uint) Combination {
+	if len(elements) == 0 {
+		return Combination{}
+	}
+	b := make([]byte, 0, len(elements))
+	b = binary.AppendUvarint(b, uint64(elements[0]))
+	for i := 1; i < len(elements); i++ {
+		b = binary.AppendUvarint(b, uint64(elements[i]-elements[i-1]-1))
+	}
+	return Combination{elements: string(b)}
+}
+
+// NewCombination returns the combination of the elements, in strictly ascending order.
+//
+// It is an error to pass elements that are not in strictly ascending order.
+func NewCombination(elements []uint) (Combination, error) {
+	if err := checkElements(elements, ^uint(0)); err != nil {
+		return Combination{}, err
+	}
+	return newCombination(elements), nil
+}
+
+// Snapshots returns an iterator over the combinations as Combination values, which remain valid once the
+// generator advances. The elements of a combination may be yielded in any order, as by KPermutations; the
+// Combination is of the distinct elements. Like the generators, when the iterator is called again, it resumes
+// at the combination for which `yield` returned false.
+func Snapshots(combinations Combinations) iter.Seq[Combination] {
+	return func(yield func(Combination) bool) {
+		var elements []uint
+		for combination := range combinations {
+			elements = slices.AppendSeq(elements[:0], combination)
+			if !slices.IsSorted(elements) {
+				slices.Sort(elements)
+			}
+			if !yield(newCombination(slices.Compact(elements))) {
+				return
+			}
+		}
+	}
+}
+
+// Contains reports whether the element is selected for the combination.
+func (c Combination) Contains(element uint) bool {
+	for e := range c.Elements() {
+		if e >= element {
+			return e == element
+		}
+	}
+	return false
+}
+
+// Len returns the number of elements of the combination, that is, k.
+func (c Combination) Len() uint {
+	count := uint(0)
+	for i := range len(c.elements) {
+		if c.elements[i] < 0x80 { // the last byte of a uvarint
+			count++
+		}
+	}
+	return count
+}
+
+// Elements returns an iterator over the elements of the combination, in ascending order.
+func (c Combination) Elements() Elements {
+	return func(yield func(uint) bool) {
+		element := uint(0)
+		for i := 0; i < len(c.elements); {
+			gap, size := uvarint(c.elements[i:])
+			if i > 0 {
+				gap++
+			}
+			element += uint(gap)
+			if !yield(element) {
+				return
+			}
+			i += size
+		}
+	}
+}
+
+// uvarint decodes a uvarint, as binary.Uvarint, from a string, returning the value and its size.
+// Precondition: `s` starts with a valid uvarint.
+func uvarint(s string) (uint64, int) {
+	var x uint64
+	for i := 0; ; i++ {
+		b := s[i]
+		x |= uint64(b&0x7f) << (7 * i)
+		if b < 0x80 {
+			return x, i + 1
+		}
+	}
+}
+
+// last returns the greatest element of the combination.
+// Precondition: the combination has elements.
+func (c Combination) last() uint {
+	var last uint
+	for last = range c.Elements() {
+	}
+	return last
+}
+
+// AppendTo appends the elements of the combination, in ascending order, to `dst` and returns the extended
+// slice.
+func (c Combination) AppendTo(dst []uint) []uint {
+	return slices.AppendSeq(dst, c.Elements())
+}
+
+// Word returns the combination represented as in `ComputerWordBig.Words()`.
+func (c Combination) Word() *big.Int {
+	if len(c.elements) == 0 {
+		return new(big.Int)
+	}
+	limbs := make([]big.Word, c.last()/bits.UintSize+1)
+	for element := range c.Elements() {
+		limbs[element/bits.UintSize] |= 1 << (element % bits.UintSize)
+	}
+	return new(big.Int).SetBits(limbs)
+}
+
+// Word64 returns the combination represented as in `ComputerWord64.Words()`.
+//
+// It is an error to call Word64 for a combination with an element greater than 62.
+func (c Combination) Word64() (int64, error) {
+	var word int64
+	for element := range c.Elements() {
+		if element > 62 {
+			return 0, fmt.Errorf("element %d greater than 62, consider using Word", c.last())
+		}
+		word |= 1 << element
+	}
+	return word, nil
+}
+
+// String returns the elements of the combination, in ascending order, for example "[0 2 5]".
+func (c Combination) String() string {
+	s := []byte{'['}
+	for element := range c.Elements() {
+		if len(s) > 1 {
+			s = append(s, ' ')
+		}
+		s = strconv.AppendUint(s, uint64(element), 10)
+	}
+	return string(append(s, ']'))
+}
+
+// Equal reports whether the combinations are of the same elements; equivalent to `c == other`.
+func (c Combination) Equal(other Combination) bool {
+	return c.elements == other.elements
+}
+
+// Hash returns a 64-bit FNV-1a hash of the combination. The hash is the same across processes and platforms.
+func (c Combination) Hash() uint64 {
+	const offset64, prime64 = 14695981039346656037, 1099511628211
+	hash := uint64(offset64)
+	for i := range len(c.elements) {
+		hash ^= uint64(c.elements[i])
+		hash *= prime64
+	}
+	return hash
+}
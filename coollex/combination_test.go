@@ -0,0 +1,132 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestSnapshots(t *testing.T) {
+	const n, k = 10, 4
+	generators := map[string]func() coollexAlgorithm{
+		"LinkedList":      func() coollexAlgorithm { g, _ := NewLinkedList(n, k); return &g },
+		"ComputerWordBig": func() coollexAlgorithm { g, _ := NewComputerWordBig(n, k); return &g },
+		"Array":           func() coollexAlgorithm { g, _ := NewArray(n, k); return &g },
+		"Sparse":          func() coollexAlgorithm { g, _ := NewSparse(n, k); return &g },
+	}
+	word, _ := NewComputerWord64(n, k)
+	expect := slices.Collect(word.Words())
+	for name, generator := range generators {
+		// retained beyond the iteration
+		snapshots := slices.Collect(Snapshots(generator().Combinations()))
+		if len(snapshots) != len(expect) {
+			t.Fatalf("%s: expected %d combinations, got %d", name, len(expect), len(snapshots))
+		}
+		for i, c := range snapshots {
+			if w, err := c.Word64(); err != nil || w != expect[i] {
+				t.Fatalf("%s: expected %#x, got %v at %d", name, expect[i], c, i)
+			}
+		}
+	}
+}
+
+func TestSnapshotsResume(t *testing.T) {
+	word, _ := NewComputerWord64(5, 2)
+	var actual []Combination
+	for c := range Snapshots(word.Combinations()) {
+		actual = append(actual, c)
+		if len(actual) == 3 {
+			break
+		}
+	}
+	// resumes at the combination for which yield returned false
+	actual = append(actual, slices.Collect(Snapshots(word.Combinations()))...)
+	if len(actual) != 11 || actual[2] != actual[3] {
+		t.Fatalf("unexpected combinations %v", actual)
+	}
+}
+
+func TestSnapshotsUnordered(t *testing.T) {
+	perm, _ := NewKPermutations(12, 2)
+	distinct := map[Combination]bool{}
+	for c := range Snapshots(perm.Arrangements()) {
+		elements := c.AppendTo(nil)
+		if len(elements) != 2 || elements[0] >= elements[1] {
+			t.Fatalf("unexpected combination %v", c)
+		}
+		distinct[c] = true
+	}
+	if len(distinct) != 66 {
+		t.Fatalf("expected 66 distinct combinations, got %d", len(distinct))
+	}
+}
+
+func TestCombinationLargeElements(t *testing.T) {
+	huge, last := ^uint(0)>>1, ^uint(0)-1
+	c, err := NewCombination([]uint{3, huge, last})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Contains(huge) || c.Contains(huge+1) || !c.Contains(last) || c.Len() != 3 {
+		t.Fatalf("unexpected combination %v", c)
+	}
+	if elements := c.AppendTo(nil); !slices.Equal(elements, []uint{3, huge, last}) {
+		t.Fatalf("unexpected elements %v", elements)
+	}
+	if _, err := c.Word64(); err == nil {
+		t.Fatal("error is expected for an element greater than 62")
+	}
+}
+
+func TestCombination(t *testing.T) {
+	c, err := NewCombination([]uint{0, 2, 9, 70})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Contains(9) || c.Contains(8) || c.Contains(1000) || c.Len() != 4 {
+		t.Fatalf("unexpected combination %v", c)
+	}
+	if s := c.String(); s != "[0 2 9 70]" {
+		t.Fatalf("expected [0 2 9 70], got %s", s)
+	}
+	if elements := c.AppendTo([]uint{42}); !slices.Equal(elements, []uint{42, 0, 2, 9, 70}) {
+		t.Fatalf("unexpected elements %v", elements)
+	}
+	expect := new(big.Int).SetBit(big.NewInt(0b1000000101), 70, 1)
+	if c.Word().Cmp(expect) != 0 {
+		t.Fatalf("expected %#x, got %#x", expect, c.Word())
+	}
+	if _, err := c.Word64(); err == nil {
+		t.Fatal("error is expected for an element greater than 62")
+	}
+
+	same, _ := NewCombination([]uint{0, 2, 9, 70})
+	other, _ := NewCombination([]uint{0, 2, 9})
+	if !c.Equal(same) || c != same || c.Hash() != same.Hash() || c.Equal(other) || c.Hash() == other.Hash() {
+		t.Fatal("unexpected equality")
+	}
+	counts := map[Combination]int{c: 1}
+	counts[same]++
+	if counts[c] != 2 {
+		t.Fatal("expected equal combinations to be the same map key")
+	}
+
+	var empty Combination
+	if empty.Len() != 0 || empty.String() != "[]" || empty.Word().Sign() != 0 {
+		t.Fatalf("unexpected empty combination %v", empty)
+	}
+	if _, err := NewCombination([]uint{3, 3}); err == nil {
+		t.Fatal("error is expected for unordered elements")
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// NodeIndex is the set of integer types that CompactLinkedList uses to link nodes.
+type NodeIndex interface {
+	uint32 | uint64
+}
+
+// successors are allocated in chunks of 2^compactChunkBits nodes
+const (
+	compactChunkBits = 20
+	compactChunkMask = 1<<compactChunkBits - 1
+)
+
+// CompactLinkedList implements the LinkedList algorithm from the paper, see section 3.2. Iterative Algorithms,
+// page 8, without pointers: nodes are linked by indices of type I, allocated in chunks, and node values are
+// packed in a bitset.
+//
+// A node takes 4 (uint32) or 8 (uint64) bytes plus one bit, compared to the LinkedList's four machine words,
+// and the garbage collector does not scan the nodes. `CompactLinkedList[uint32]` allows for `n<2^32-1`.
+//
+// Unlike LinkedList, CompactLinkedList does not track the value-true nodes: yielding the elements of a
+// combination traverses the list up to the last selected element.
+type CompactLinkedList[I NodeIndex] struct {
+	successors [][]I    // the successor of each node, or `nilIndex`
+	values     []uint64 // the value of each node
+	// b, x are named as found in the research paper, see LinkedList
+	b, x  I
+	t     uint // number of value-true nodes
+	ended bool // whether all combinations have been yielded
+}
+
+// nilIndex returns the index that links to no node
+func nilIndex[I NodeIndex]() I {
+	return ^I(0)
+}
+
+func (list *CompactLinkedList[I]) successor(i I) I {
+	return list.successors[i>>compactChunkBits][i&compactChunkMask]
+}
+
+func (list *CompactLinkedList[I]) link(i, successor I) {
+	list.successors[i>>compactChunkBits][i&compactChunkMask] = successor
+}
+
+func (list *CompactLinkedList[I]) value(i I) bool {
+	return list.values[i/64]&(1<<(i%64)) != 0
+}
+
+// newCompactLinkedList creates a new CompactLinkedList with the specified number of 0-bits (s) and number of
+// 1-bits (t; precondition: t>0).
+func newCompactLinkedList[I NodeIndex](s, t uint) CompactLinkedList[I] {
+	// initial state: ones to the head, zeros to the tail; node i at position i
+	size := s + t
+	successors := make([][]I, 0, (size+compactChunkMask)>>compactChunkBits)
+	for start := uint(0); start < size; start += 1 << compactChunkBits {
+		chunk := make([]I, min(size-start, 1<<compactChunkBits))
+		for i := range chunk {
+			chunk[i] = I(start) + I(i) + 1
+		}
+		successors = append(successors, chunk)
+	}
+	last := successors[len(successors)-1]
+	last[len(last)-1] = nilIndex[I]()
+
+	values := make([]uint64, (size+63)/64)
+	for i := range t / 64 {
+		values[i] = ^uint64(0)
+	}
+	if t%64 != 0 {
+		values[t/64] = 1<<(t%64) - 1
+	}
+	return CompactLinkedList[I]{
+		successors: successors,
+		values:     values,
+		b:          0,
+		x:          I(t - 1),
+		t:          t,
+	}
+}
+
+// hasNext reports whether more combinations are available
+func (list *CompactLinkedList[I]) hasNext() bool {
+	return list.successor(list.x) != nilIndex[I]()
+}
+
+// next advances to the next combination in cool-lex order
+func (list *CompactLinkedList[I]) next() {
+	y := list.successor(list.x)
+	list.link(list.x, list.successor(y))
+	list.link(y, list.b)
+	list.b = y
+
+	if b1 := list.successor(y); !list.value(y) && list.value(b1) {
+		list.x = b1
+	}
+}
+
+// Elements returns an iterator over the elements selected for the current combination.
+func (list *CompactLinkedList[I]) Elements() Elements {
+	b, t := list.b, list.t
+	return func(yield func(uint) bool) {
+		var i uint
+		for curr := b; t > 0; curr = list.successor(curr) {
+			if list.value(curr) {
+				if !yield(i) {
+					break
+				}
+				t--
+			}
+			i++
+		}
+	}
+}
+
+// Combinations returns an iterator over the generated combinations.
+func (list *CompactLinkedList[I]) Combinations() Combinations {
+	// k=0 -> the list has no nodes
+	if list.successors == nil {
+		return func(yield func(Elements) bool) {}
+	}
+	return func(yield func(Elements) bool) {
+		//the algorithm is initially positioned at the first combination
+		for !list.ended && yield(list.Elements()) {
+			if list.ended = !list.hasNext(); !list.ended {
+				list.next()
+			}
+		}
+	}
+}
+
+// NewCompactLinkedList returns a combinations generator that yields combinations in Cool-lex order, implementing
+// internally the LinkedList Cool-lex algorithm, with nodes linked by indices of type I.
+//
+// n: number of elements to combine; n>=k must hold.
+//
+// k: number of elements in each combination.
+//
+// It is an error to pass arguments such that n < k.
+// It is an error to pass arguments such that n is not less than the greatest value of type I.
+func NewCompactLinkedList[I NodeIndex](n, k uint) (CompactLinkedList[I], error) {
+	if n < k {
+		return CompactLinkedList[I]{}, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	if limit := uint64(nilIndex[I]()); uint64(n) >= limit {
+		return CompactLinkedList[I]{}, fmt.Errorf("n (%d) not less than %d, consider using uint64 indices", n, limit)
+	}
+	if k == 0 {
+		return CompactLinkedList[I]{}, nil
+	}
+	return newCompactLinkedList[I](n-k, k), nil
+}
+
+func (list *CompactLinkedList[I]) current() bool {
+	return list.successors != nil && !list.ended
+}
+
+func (list *CompactLinkedList[I]) step() {
+	if list.ended = !list.hasNext(); !list.ended {
+		list.next()
+	}
+}
+
+func (list *CompactLinkedList[I]) size() uint {
+	var n uint
+	for _, chunk := range list.successors {
+		n += uint(len(chunk))
+	}
+	return n
+}
+
+func (list *CompactLinkedList[I]) reposition(elements []uint) {
+	// relink the nodes as initially, node i at position i
+	for c, chunk := range list.successors {
+		start := I(c) << compactChunkBits
+		for i := range chunk {
+			chunk[i] = start + I(i) + 1
+		}
+	}
+	last := list.successors[len(list.successors)-1]
+	last[len(last)-1] = nilIndex[I]()
+
+	clear(list.values)
+	for _, element := range elements {
+		list.values[element/64] |= 1 << (element % 64)
+	}
+	list.b, list.x = 0, I(firstOfRise(elements))
+	list.ended = false
+}
+
+func (list *CompactLinkedList[I]) end() {
+	list.ended = true
+}
+
+// Advance skips `m` combinations, see ComputerWord64.Advance.
+func (list *CompactLinkedList[I]) Advance(m uint64) {
+	advance(list, m)
+}
+
+// AdvanceBig skips `m` combinations, see ComputerWord64.Advance.
+//
+// It is an error to pass a negative `m`.
+func (list *CompactLinkedList[I]) AdvanceBig(m *big.Int) error {
+	return advanceBig(list, m)
+}
+
+// Stride returns an iterator over every m-th generated combination, see ComputerWord64.Stride.
+func (list *CompactLinkedList[I]) Stride(m uint64) Combinations {
+	return stride(list, m)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"testing"
+)
+
+func BenchmarkCompactLinkedList(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		list, _ := NewCompactLinkedList[uint32](benchAlgorithmN, benchAlgorithmK)
+		for list.hasNext() {
+			list.next()
+		}
+	}
+}
+
+func BenchmarkNewCompactLinkedListMedium(b *testing.B) {
+	md := benchMarkNewLinkedListMedium / 3
+	for i := 0; i < b.N; i++ {
+		_ = newCompactLinkedList[uint32](md*2, md)
+	}
+}
+
+func BenchmarkNewCompactLinkedListHigh(b *testing.B) {
+	up := benchmarkNewLinkedListHigh / 3
+	for i := 0; i < b.N; i++ {
+		_ = newCompactLinkedList[uint32](up*2, up)
+	}
+}
+
+func TestCompactLinkedList(t *testing.T) {
+	newList32 := func(n, k uint) (coollexAlgorithm, error) {
+		list, err := NewCompactLinkedList[uint32](n, k)
+		return &list, err
+	}
+	testCoollex(t, newList32)
+	testOrder(t, newList32, largeCases...)
+	testCoollex(t, func(n, k uint) (coollexAlgorithm, error) {
+		list, err := NewCompactLinkedList[uint64](n, k)
+		return &list, err
+	})
+	if _, err := NewCompactLinkedList[uint32](math.MaxUint32, 1); err == nil {
+		t.Fatal("error is expected for n>=2^32-1")
+	}
+}
+
+// TestCompactLinkedListChunks verifies the first combinations of a list spanning multiple chunks, against
+// those of LinkedList.
+func TestCompactLinkedListChunks(t *testing.T) {
+	const n, k = 1<<compactChunkBits + 5, 1<<compactChunkBits + 3
+	expect, _ := NewLinkedList(n, k)
+	next, stop := iter.Pull(expect.Combinations())
+	defer stop()
+	actual, _ := NewCompactLinkedList[uint32](n, k)
+	count := 0
+	for combination := range actual.Combinations() {
+		expectElements, _ := next()
+		if e, a := slices.Collect(expectElements), slices.Collect(combination); !slices.Equal(e, a) {
+			t.Fatalf("combination %d: expected %v, got %v", count, e, a)
+		}
+		if count++; count == 50 {
+			break
+		}
+	}
+}
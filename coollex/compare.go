@@ -0,0 +1,124 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Comparison follows the recursive definition of the cool-lex order, see Rank. Let p be the greatest position
+// at which two combinations differ: the combination with a 0-bit at p precedes the other one, as the strings
+// ending with a 0-bit precede the strings ending with a 1-bit. Unless the combinations agree on a 1-bit above p,
+// for then the strings are in rotated order: the first one, with the 1-bits leading, moves to the end.
+//
+// Hence, the comparison does not depend on n: the order of the combinations of k out of n elements is a prefix
+// of the order of the combinations of k out of m>n elements. The functions compare combinations of the same k;
+// they are consistent with Rank, and can be passed to `slices.SortFunc` and `slices.BinarySearchFunc`, Compare64
+// with n bound by a closure.
+
+// Compare64 compares two combinations, of the same k, represented as in `ComputerWord64.Words()`, by their
+// positions in Cool-lex order. The result is -1 if `a` precedes `b`, 0 if they are equal, and +1 otherwise.
+//
+// n: the number of elements, n<64; the result does not depend on it, as the order does not.
+func Compare64(a, b int64, n uint) int {
+	x, y := uint64(a), uint64(b)
+	d := x ^ y
+	if d == 0 {
+		return 0
+	}
+	p := uint(bits.Len64(d) - 1)
+	sign, zero := -1, x // zero: the combination with a 0-bit at p
+	if x>>p&1 == 1 {
+		sign, zero = 1, y
+	}
+	if rotated := x>>p > 1; rotated {
+		// the first of the strings: the bits [0, t) set
+		if low := zero & (1<<p - 1); low&(low+1) == 0 {
+			sign = -sign
+		}
+	}
+	return sign
+}
+
+// CompareBig compares two combinations, of the same k, represented as in `ComputerWordBig.Words()`, by their
+// positions in Cool-lex order, see Compare64.
+func CompareBig(a, b *big.Int) int {
+	x, y := a.Bits(), b.Bits()
+	p := -1
+	for i := max(len(x), len(y)) - 1; i >= 0; i-- {
+		if d := limb(x, i) ^ limb(y, i); d != 0 {
+			p = i*bits.UintSize + bits.Len(uint(d)) - 1
+			break
+		}
+	}
+	if p < 0 {
+		return 0
+	}
+	sign, zero := -1, x
+	if a.Bit(p) == 1 {
+		sign, zero = 1, y
+	}
+	if rotated := a.BitLen() > p+1; rotated && leadingOnes(zero, p) {
+		sign = -sign
+	}
+	return sign
+}
+
+// limb returns the i-th limb, or 0 beyond the limbs
+func limb(limbs []big.Word, i int) big.Word {
+	if i < len(limbs) {
+		return limbs[i]
+	}
+	return 0
+}
+
+// leadingOnes reports whether the bits [0, p) are of the form: the bits [0, t) set, the others clear
+func leadingOnes(limbs []big.Word, p int) bool {
+	ones, length := 0, 0
+	for i := 0; i < len(limbs) && i*bits.UintSize < p; i++ {
+		w := uint(limbs[i])
+		if (i+1)*bits.UintSize > p {
+			w &= 1<<(p%bits.UintSize) - 1
+		}
+		ones += bits.OnesCount(w)
+		if w != 0 {
+			length = i*bits.UintSize + bits.Len(w)
+		}
+	}
+	return ones == length
+}
+
+// CompareElements compares two combinations, of the same k, of elements in strictly ascending order, by their
+// positions in Cool-lex order, see Compare64.
+func CompareElements(a, b []uint) int {
+	i, j := len(a)-1, len(b)-1
+	for i >= 0 && j >= 0 && a[i] == b[j] {
+		i--
+		j--
+	}
+	if i < 0 && j < 0 {
+		return 0
+	}
+	rotated := i < len(a)-1
+	// the combination with a 0-bit at p, the greatest position at which they differ, and its greatest element
+	// below p
+	sign, zero, top := -1, a, i
+	if j < 0 || i >= 0 && a[i] > b[j] {
+		sign, zero, top = 1, b, j
+	}
+	if rotated && (top < 0 || zero[top] == uint(top)) {
+		sign = -sign
+	}
+	return sign
+}
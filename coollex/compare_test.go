@@ -0,0 +1,71 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"cmp"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	for _, tc := range []struct{ n, k uint }{{1, 1}, {4, 2}, {7, 3}, {9, 1}, {9, 8}, {10, 5}} {
+		word, _ := NewComputerWord64(tc.n, tc.k)
+		words := slices.Collect(word.Words())
+		for i, a := range words {
+			for j, b := range words {
+				expect := cmp.Compare(i, j)
+				if actual := Compare64(a, b, tc.n); actual != expect {
+					t.Fatalf("n=%d, k=%d: Compare64(%#x, %#x): expected %d, got %d", tc.n, tc.k, a, b, expect, actual)
+				}
+				if actual := CompareBig(big.NewInt(a), big.NewInt(b)); actual != expect {
+					t.Fatalf("n=%d, k=%d: CompareBig(%#x, %#x): expected %d, got %d", tc.n, tc.k, a, b, expect, actual)
+				}
+				ea, eb := slices.Collect(elements64(a)), slices.Collect(elements64(b))
+				if actual := CompareElements(ea, eb); actual != expect {
+					t.Fatalf("n=%d, k=%d: CompareElements(%v, %v): expected %d, got %d", tc.n, tc.k, ea, eb, expect, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestCompareSort(t *testing.T) {
+	const n, k = 150, 4
+	src := rand.NewPCG(5, 8)
+	words := make([]*big.Int, 500)
+	for i := range words {
+		words[i], _ = RandomWordBig(src, n, k)
+	}
+	slices.SortFunc(words, CompareBig)
+	var previous *big.Int
+	for _, w := range words {
+		var elements []uint
+		for i := range uint(n) {
+			if w.Bit(int(i)) == 1 {
+				elements = append(elements, i)
+			}
+		}
+		rank, _ := RankBig(elements, n)
+		if previous != nil && rank.Cmp(previous) < 0 {
+			t.Fatalf("rank %v after rank %v", rank, previous)
+		}
+		previous = rank
+	}
+
+	target := words[123]
+	if i, found := slices.BinarySearchFunc(words, target, CompareBig); !found || words[i].Cmp(target) != 0 {
+		t.Fatalf("expected %#x to be found, got %d, %v", target, i, found)
+	}
+}
@@ -16,7 +16,9 @@ import (
 	"fmt"
 	"iter"
 	"math"
+	"math/big"
 	"math/bits"
+	"slices"
 )
 
 // ComputerWord32 implements the register-based (computer words) algorithm from the paper,
@@ -24,6 +26,9 @@ import (
 // The implementation here is based on 32-bit registers, allowing for `n<=31`.
 type ComputerWord32 struct {
 	r2, r3 int32 // names as in the paper; r2 is mask, r3 stores the combination
+	// of the generators of an interval, or cyclic ones: the combination to stop at, and the one to stop at once
+	// wrapped around past the last combination; 0 for none
+	stop, cycle int32
 }
 
 // hasNext reports whether more combinations are available
@@ -31,6 +36,22 @@ func (word *ComputerWord32) hasNext() bool {
 	return (word.r3 & word.r2) == 0
 }
 
+// wrap positions a cyclic generator, past the last combination, at the first one, and reports whether it is
+// yet to be yielded
+func (word *ComputerWord32) wrap() bool {
+	if word.cycle == 0 {
+		return false
+	}
+	// past the last combination, 1^(k-1) 0^(n-k) 1 shifted to 0 1^(k-1) 0^(n-k) 1: the first one is 1^k 0^(n-k)
+	word.r3 = (word.r3 ^ word.r2) + 1
+	word.wrapped()
+	return word.r3 != word.stop
+}
+
+func (word *ComputerWord32) wrapped() {
+	word.stop, word.cycle = word.cycle, 0
+}
+
 // next advances to the next combination in cool-lex order
 func (word *ComputerWord32) next() {
 	r3 := word.r3
@@ -70,7 +91,7 @@ func elements32(v int32) Elements {
 func newComputerWord32(s, t uint) ComputerWord32 {
 	var r2 int32 = 1 << (s + t)
 	var r3 int32 = (1 << t) - 1
-	return ComputerWord32{r2, r3}
+	return ComputerWord32{r2: r2, r3: r3}
 }
 
 // Elements returns an iterator over the elements selected for the current combination.
@@ -81,6 +102,12 @@ func (word *ComputerWord32) Elements() Elements {
 // Combinations returns an iterator over the generated combinations.
 func (word *ComputerWord32) Combinations() Combinations {
 	return func(yield func(Elements) bool) {
+		if word.stop != 0 || word.cycle != 0 {
+			for word.current() && yield(word.Elements()) {
+				word.next()
+			}
+			return
+		}
 		for word.hasNext() && yield(word.Elements()) {
 			word.next()
 		}
@@ -94,6 +121,12 @@ func (word *ComputerWord32) Combinations() Combinations {
 //     most-significant other bits are cleared
 func (word *ComputerWord32) Words() iter.Seq[int32] {
 	return func(yield func(int32) bool) {
+		if word.stop != 0 || word.cycle != 0 {
+			for word.current() && yield(word.r3) {
+				word.next()
+			}
+			return
+		}
 		for word.hasNext() && yield(word.r3) {
 			word.next()
 		}
@@ -117,7 +150,134 @@ func NewComputerWord32(n, k uint) (ComputerWord32, error) {
 		return ComputerWord32{}, fmt.Errorf("n (%d) greater than 31, consider using LinkedList", n)
 	}
 	if k == 0 {
-		return ComputerWord32{math.MinInt32, math.MinInt32}, nil // anything such that r2&r3 != 0
+		return ComputerWord32{r2: math.MinInt32, r3: math.MinInt32}, nil // anything such that r2&r3 != 0
 	}
 	return newComputerWord32(n-k, k), nil
 }
+
+// current reports whether the generator is positioned at a combination yet to be yielded, within its limits,
+// if any
+func (word *ComputerWord32) current() bool {
+	if word.r3&word.r2 != 0 {
+		return word.wrap()
+	}
+	return word.r3 != word.stop
+}
+
+func (word *ComputerWord32) step() {
+	word.next()
+}
+
+func (word *ComputerWord32) size() uint {
+	return uint(bits.TrailingZeros32(uint32(word.r2)))
+}
+
+func (word *ComputerWord32) reposition(elements []uint) {
+	word.r3 = toWord32(elements)
+}
+
+// toWord32 returns the word with the bits at the positions `elements` set.
+func toWord32(elements []uint) int32 {
+	var word int32
+	for _, element := range elements {
+		word |= 1 << element
+	}
+	return word
+}
+
+func (word *ComputerWord32) end() {
+	word.r3 |= word.r2
+	word.cycle = 0
+}
+
+// Advance skips `m` combinations, see ComputerWord64.Advance.
+func (word *ComputerWord32) Advance(m uint64) {
+	advance(word, m)
+}
+
+// AdvanceBig skips `m` combinations, see ComputerWord64.Advance.
+//
+// It is an error to pass a negative `m`.
+func (word *ComputerWord32) AdvanceBig(m *big.Int) error {
+	return advanceBig(word, m)
+}
+
+// Stride returns an iterator over every m-th generated combination, see ComputerWord64.Stride.
+func (word *ComputerWord32) Stride(m uint64) Combinations {
+	return stride(word, m)
+}
+
+func (word *ComputerWord32) limits() ([]uint, bool) {
+	switch {
+	case word.cycle != 0:
+		return slices.Collect(elements32(word.cycle)), true
+	case word.stop != 0:
+		return slices.Collect(elements32(word.stop)), false
+	}
+	return nil, false
+}
+
+// Count returns the number of combinations that the generator is yet to yield, see ComputerWord64.Count.
+func (word *ComputerWord32) Count() uint64 {
+	return remaining(word).Uint64()
+}
+
+// NewComputerWord32Range returns a combinations generator, see NewComputerWord32, that yields the combinations
+// at positions in the interval [start, end) in Cool-lex order.
+//
+// It is an error to pass arguments such that n < k, n >= 32, k = 0, or the interval is out of range
+// [0, C(n,k)].
+func NewComputerWord32Range(n, k, start, end uint) (ComputerWord32, error) {
+	if n >= 32 {
+		return ComputerWord32{}, fmt.Errorf("n (%d) greater than 31, consider using LinkedList", n)
+	}
+	first, stop, err := interval(n, k, new(big.Int).SetUint64(uint64(start)), new(big.Int).SetUint64(uint64(end)))
+	if err != nil {
+		return ComputerWord32{}, err
+	}
+	word := newComputerWord32(n-k, k)
+	if first == nil {
+		word.end()
+		return word, nil
+	}
+	word.reposition(first)
+	word.stop = toWord32(stop) // 0 for no stop
+	return word, nil
+}
+
+// NewComputerWord32Between returns a combinations generator, see NewComputerWord32, that yields the
+// combinations from the combination of the elements `start`, inclusive, to the combination of the elements
+// `end`, exclusive, see NewComputerWord64Between.
+//
+// It is an error to pass arguments such that n >= 32, the combinations are of different or no elements,
+// out-of-range elements, or `end` precedes `start`.
+func NewComputerWord32Between(n uint, start, end []uint) (ComputerWord32, error) {
+	startRank, endRank, err := intervalRanks(n, start, end)
+	if err != nil {
+		return ComputerWord32{}, err
+	}
+	if n >= 32 {
+		return ComputerWord32{}, fmt.Errorf("n (%d) greater than 31, consider using LinkedList", n)
+	}
+	return NewComputerWord32Range(n, uint(len(start)), uint(startRank.Uint64()), uint(endRank.Uint64()))
+}
+
+// NewComputerWord32Cyclic returns a combinations generator, see NewComputerWord32, that yields all the C(n,k)
+// combinations in Cool-lex order, starting at the combination at position `rank`, see NewComputerWord64Cyclic.
+//
+// It is an error to pass arguments such that n < k, n >= 32, k = 0, or `rank` is not less than C(n,k).
+func NewComputerWord32Cyclic(n, k, rank uint) (ComputerWord32, error) {
+	if n >= 32 {
+		return ComputerWord32{}, fmt.Errorf("n (%d) greater than 31, consider using LinkedList", n)
+	}
+	elements, err := Unrank(rank, n, k)
+	if err != nil {
+		return ComputerWord32{}, err
+	}
+	word := newComputerWord32(n-k, k)
+	word.reposition(elements)
+	if rank > 0 {
+		word.cycle = word.r3
+	}
+	return word, nil
+}
@@ -16,7 +16,9 @@ import (
 	"fmt"
 	"iter"
 	"math"
+	"math/big"
 	"math/bits"
+	"slices"
 )
 
 // ComputerWord64 implements the register-based (computer words) algorithm from the paper,
@@ -24,6 +26,9 @@ import (
 // The implementation here is based on 64-bit registers, allowing for `n<=63`.
 type ComputerWord64 struct {
 	r2, r3 int64 // names as in the paper; r2 is mask, r3 stores the combination
+	// of the generators of an interval, or cyclic ones: the combination to stop at, and the one to stop at once
+	// wrapped around past the last combination; 0 for none
+	stop, cycle int64
 }
 
 // hasNext reports whether more combinations are available
@@ -31,6 +36,22 @@ func (word *ComputerWord64) hasNext() bool {
 	return (word.r3 & word.r2) == 0
 }
 
+// wrap positions a cyclic generator, past the last combination, at the first one, and reports whether it is
+// yet to be yielded
+func (word *ComputerWord64) wrap() bool {
+	if word.cycle == 0 {
+		return false
+	}
+	// past the last combination, 1^(k-1) 0^(n-k) 1 shifted to 0 1^(k-1) 0^(n-k) 1: the first one is 1^k 0^(n-k)
+	word.r3 = (word.r3 ^ word.r2) + 1
+	word.wrapped()
+	return word.r3 != word.stop
+}
+
+func (word *ComputerWord64) wrapped() {
+	word.stop, word.cycle = word.cycle, 0
+}
+
 // next advances to the next combination in cool-lex order
 func (word *ComputerWord64) next() {
 	r3 := word.r3
@@ -70,7 +91,7 @@ func elements64(v int64) Elements {
 func newComputerWord64(s, t uint) ComputerWord64 {
 	var r2 int64 = 1 << (s + t)
 	var r3 int64 = (1 << t) - 1
-	return ComputerWord64{r2, r3}
+	return ComputerWord64{r2: r2, r3: r3}
 }
 
 // Elements returns an iterator over the elements selected for the current combination.
@@ -81,6 +102,12 @@ func (word *ComputerWord64) Elements() Elements {
 // Combinations returns an iterator over the generated combinations.
 func (word *ComputerWord64) Combinations() Combinations {
 	return func(yield func(Elements) bool) {
+		if word.stop != 0 || word.cycle != 0 {
+			for word.current() && yield(word.Elements()) {
+				word.next()
+			}
+			return
+		}
 		for word.hasNext() && yield(word.Elements()) {
 			word.next()
 		}
@@ -94,6 +121,12 @@ func (word *ComputerWord64) Combinations() Combinations {
 //     most-significant other bits are cleared
 func (word *ComputerWord64) Words() iter.Seq[int64] {
 	return func(yield func(int64) bool) {
+		if word.stop != 0 || word.cycle != 0 {
+			for word.current() && yield(word.r3) {
+				word.next()
+			}
+			return
+		}
 		for word.hasNext() && yield(word.r3) {
 			word.next()
 		}
@@ -118,7 +151,157 @@ func NewComputerWord64(n, k uint) (ComputerWord64, error) {
 	}
 
 	if k == 0 {
-		return ComputerWord64{math.MinInt64, math.MinInt64}, nil // anything such that r2&r3 != 0
+		return ComputerWord64{r2: math.MinInt64, r3: math.MinInt64}, nil // anything such that r2&r3 != 0
 	}
 	return newComputerWord64(n-k, k), nil
 }
+
+// NewComputerWord64At returns a combinations generator, see NewComputerWord64, positioned at the combination at
+// position `rank` in Cool-lex order. The generator yields that combination first.
+//
+// It is an error to pass arguments such that n < k, n >= 64, k = 0, or `rank` is not less than C(n,k).
+func NewComputerWord64At(n, k, rank uint) (ComputerWord64, error) {
+	word, err := NewComputerWord64(n, k)
+	if err != nil {
+		return word, err
+	}
+	elements, err := Unrank(rank, n, k)
+	if err != nil {
+		return ComputerWord64{}, err
+	}
+	word.r3 = toWord64(elements)
+	return word, nil
+}
+
+// toWord64 returns the word with the bits at the positions `elements` set.
+func toWord64(elements []uint) int64 {
+	var word int64
+	for _, element := range elements {
+		word |= 1 << element
+	}
+	return word
+}
+
+// current reports whether the generator is positioned at a combination yet to be yielded, within its limits,
+// if any
+func (word *ComputerWord64) current() bool {
+	if word.r3&word.r2 != 0 {
+		return word.wrap()
+	}
+	return word.r3 != word.stop
+}
+
+func (word *ComputerWord64) step() {
+	word.next()
+}
+
+func (word *ComputerWord64) size() uint {
+	return uint(bits.TrailingZeros64(uint64(word.r2)))
+}
+
+func (word *ComputerWord64) reposition(elements []uint) {
+	word.r3 = toWord64(elements)
+}
+
+func (word *ComputerWord64) end() {
+	word.r3 |= word.r2
+	word.cycle = 0
+}
+
+// Advance skips `m` combinations: the generator yields next the combination `m` positions after the one that it
+// would have yielded, or ends if there is none. Rather than stepping through the combinations, the generator
+// is repositioned via ranking, in O(n) arithmetic operations regardless of `m`.
+func (word *ComputerWord64) Advance(m uint64) {
+	advance(word, m)
+}
+
+// AdvanceBig skips `m` combinations, see Advance.
+//
+// It is an error to pass a negative `m`.
+func (word *ComputerWord64) AdvanceBig(m *big.Int) error {
+	return advanceBig(word, m)
+}
+
+// Stride returns an iterator over every m-th generated combination, starting with the one that the generator
+// would yield next; `m=0` is treated as `m=1`. See Advance.
+func (word *ComputerWord64) Stride(m uint64) Combinations {
+	return stride(word, m)
+}
+
+func (word *ComputerWord64) limits() ([]uint, bool) {
+	switch {
+	case word.cycle != 0:
+		return slices.Collect(elements64(word.cycle)), true
+	case word.stop != 0:
+		return slices.Collect(elements64(word.stop)), false
+	}
+	return nil, false
+}
+
+// Count returns the number of combinations that the generator is yet to yield; initially, for a generator of
+// an interval, the number of combinations in the interval.
+func (word *ComputerWord64) Count() uint64 {
+	return remaining(word).Uint64()
+}
+
+// NewComputerWord64Range returns a combinations generator, see NewComputerWord64, that yields the combinations
+// at positions in the interval [start, end) in Cool-lex order.
+//
+// It is an error to pass arguments such that n < k, n >= 64, k = 0, or the interval is out of range
+// [0, C(n,k)].
+func NewComputerWord64Range(n, k, start, end uint) (ComputerWord64, error) {
+	if n >= 64 {
+		return ComputerWord64{}, fmt.Errorf("n (%d) greater than 63, consider using LinkedList", n)
+	}
+	first, stop, err := interval(n, k, new(big.Int).SetUint64(uint64(start)), new(big.Int).SetUint64(uint64(end)))
+	if err != nil {
+		return ComputerWord64{}, err
+	}
+	word := newComputerWord64(n-k, k)
+	if first == nil {
+		word.end()
+		return word, nil
+	}
+	word.reposition(first)
+	word.stop = toWord64(stop) // 0 for no stop
+	return word, nil
+}
+
+// NewComputerWord64Between returns a combinations generator, see NewComputerWord64, that yields the
+// combinations from the combination of the elements `start`, inclusive, to the combination of the elements
+// `end`, exclusive, in Cool-lex order; or to the last combination, inclusive, for no `end`. The combinations
+// are of k=len(start) elements, in strictly ascending order.
+//
+// It is an error to pass arguments such that n >= 64, the combinations are of different or no elements,
+// out-of-range elements, or `end` precedes `start`.
+func NewComputerWord64Between(n uint, start, end []uint) (ComputerWord64, error) {
+	startRank, endRank, err := intervalRanks(n, start, end)
+	if err != nil {
+		return ComputerWord64{}, err
+	}
+	if n >= 64 {
+		return ComputerWord64{}, fmt.Errorf("n (%d) greater than 63, consider using LinkedList", n)
+	}
+	return NewComputerWord64Range(n, uint(len(start)), uint(startRank.Uint64()), uint(endRank.Uint64()))
+}
+
+// NewComputerWord64Cyclic returns a combinations generator, see NewComputerWord64, that yields all the C(n,k)
+// combinations in Cool-lex order, starting at the combination at position `rank`, and following the last
+// combination by the first one.
+//
+// It is an error to pass arguments such that n < k, n >= 64, k = 0, or `rank` is not less than C(n,k).
+func NewComputerWord64Cyclic(n, k, rank uint) (ComputerWord64, error) {
+	if n >= 64 {
+		return ComputerWord64{}, fmt.Errorf("n (%d) greater than 63, consider using LinkedList", n)
+	}
+	elements, err := Unrank(rank, n, k)
+	if err != nil {
+		return ComputerWord64{}, err
+	}
+	word := newComputerWord64(n-k, k)
+	word.reposition(elements)
+	if rank > 0 {
+		word.cycle = word.r3
+	}
+	return word, nil
+}
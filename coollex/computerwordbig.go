@@ -16,93 +16,173 @@ import (
 	"fmt"
 	"iter"
 	"math/big"
+	"math/bits"
+	"slices"
 )
 
-// ComputerWordBig implements the register-based (computer words) algorithm presented in the paper,
-// see 3.3. Implementation in Computer Words, page 10.
-// The implementation here is based on `big.Int`, allowing for arbitrary `n` and maintaining
-// internally a state of just four `big.Int` numbers.
+// ComputerWordBig yields the combinations of the register-based (computer words) algorithm presented in the
+// paper, see 3.3. Implementation in Computer Words, page 10, as `big.Int` words, allowing for arbitrary `n`.
+//
+// Rather than performing full-width arithmetic, the implementation applies each prefix rotation as at most
+// four single-bit updates, tracking the length of the prefix of 1-bits and the position of the first 1-bit
+// that follows it. A step thus touches O(1) words, regardless of `n`.
 type ComputerWordBig struct {
-	// names as in the paper
-	r2, r3 *big.Int // r2 is a mask, r3 stores the combination
-	r0, r1 *big.Int // auxiliaries, kept in the struct to avoid memory allocations
-}
+	r3    *big.Int   // name as in the paper; stores the combination, backed by `words`
+	words []big.Word // the combination, least-significant word first
+	top   int        // number of words up to, and including, the most-significant nonzero word
 
-var bigOne = big.NewInt(1)
+	n, t uint // number of elements (n), and number of 1-bits (t)
+	// p: length of the prefix of 1-bits
+	// j: position of the first 1-bit after the prefix's trailing 0-bit, when p<t; j>=n once ended
+	p, j uint
 
-// hasNext reports whether more combinations are available
-func (word *ComputerWordBig) hasNext() bool {
-	word.r0.And(word.r3, word.r2)
-	return len(word.r0.Bits()) == 0
+	// of the generators of an interval, or cyclic ones: the combination to stop at, and the one to stop at once
+	// wrapped around past the last combination; nil for none
+	stop, cycle *ComputerWordBig
 }
 
-// next advances to the next combination in cool-lex order
-func (word *ComputerWordBig) next() {
+// bit reports whether the bit at position i is set
+func (word *ComputerWordBig) bit(i uint) bool {
+	return word.words[i/bits.UintSize]&(1<<(i%bits.UintSize)) != 0
+}
 
-	// 1: r0 := r3 & (r3 + 1)
-	// 2: r1 := r0 ^ (r0 - 1)
-	// 3: r0 = r1 + 1
-	// 4: r1 = r1 & r3
-	// 5: r0 = DOZ(r0&r3, 1)
-	// 6: r3 = r3 + r1 - r0
+// setBit sets the bit at position i
+func (word *ComputerWordBig) setBit(i uint) {
+	w := int(i / bits.UintSize)
+	word.words[w] |= 1 << (i % bits.UintSize)
+	word.top = max(word.top, w+1)
+}
 
-	r3 := word.r3
-	r0 := word.r0.Set(r3)
-	r0.Add(r0, bigOne).And(r0, r3)
+// clearBit clears the bit at position i.
+// Precondition: the most-significant set bit remains set.
+func (word *ComputerWordBig) clearBit(i uint) {
+	word.words[i/bits.UintSize] &^= 1 << (i % bits.UintSize)
+}
 
-	r1 := word.r1.Set(r0)
-	r1.Sub(r1, bigOne).Xor(r1, r0)
+// hasNext reports whether more combinations are available
+func (word *ComputerWordBig) hasNext() bool {
+	return word.j < word.n
+}
 
-	r0.Add(r1, bigOne)
-	r1.And(r1, r3)
+// at reports whether the algorithm is positioned at the combination of the other one
+func (word *ComputerWordBig) at(other *ComputerWordBig) bool {
+	return word.p == other.p && slices.Equal(word.words, other.words)
+}
 
-	r0.And(r0, r3)
-	if r0.Cmp(bigOne) >= 0 {
-		r0.Sub(r0, bigOne)
+// wrap positions a cyclic generator, past the last combination, at the first one, and reports whether it is
+// yet to be yielded
+func (word *ComputerWordBig) wrap() bool {
+	if word.cycle == nil {
+		return false
 	}
+	word.rewind()
+	word.wrapped()
+	return !word.at(word.stop)
+}
+
+func (word *ComputerWordBig) wrapped() {
+	word.stop, word.cycle = word.cycle, nil
+}
 
-	word.r3.Add(r3, r1).Sub(r3, r0)
+// next advances to the next combination in cool-lex order.
+//
+// The successor rotates, by one position, the shortest prefix that ends with 010 or 011; or the
+// whole string, when there is no such prefix. The most-significant set bit never moves down.
+func (word *ComputerWordBig) next() {
+	p, j := word.p, word.j
+	switch {
+	case p == word.t: // 1^p 0 -> 0 1^p
+		if p == word.n {
+			word.j = word.n
+			return
+		}
+		word.clearBit(0)
+		word.setBit(p)
+		word.p, word.j = 0, 1
+	case j+1 < word.n && word.bit(j+1): // 1^p 0^q 1 1 -> 1 1^p 0^q 1
+		word.setBit(p)
+		word.clearBit(j)
+		word.p, word.j = p+1, j+1
+	case j+1 == word.n: // the last combination
+		word.j = word.n
+	case p == 0: // 0^q 1 0 -> 0 0^q 1
+		word.setBit(j + 1)
+		word.clearBit(j)
+		word.j = j + 1
+	default: // 1^p 0^q 1 0 -> 0 1^p 0^q 1
+		word.setBit(j + 1)
+		word.clearBit(j)
+		word.clearBit(0)
+		word.setBit(p)
+		word.p, word.j = 0, 1
+	}
 }
 
 // newComputerWordBig initializes the algorithm for the specified number of 0-bits (s) and number of 1-bits (t).
 // Precondition: `t>0`.
 func newComputerWordBig(s, t uint) ComputerWordBig {
-	aux := make([]big.Int, 4)
+	n := s + t
+	word := ComputerWordBig{
+		r3:    new(big.Int),
+		words: make([]big.Word, (n+bits.UintSize-1)/bits.UintSize),
+		n:     n,
+		t:     t,
+		p:     t,
+	}
+	for i := range t {
+		word.setBit(i)
+	}
+	return word
+}
+
+// rewind positions the algorithm at the first combination
+func (word *ComputerWordBig) rewind() {
+	clear(word.words)
+	word.top = 0
+	for i := range word.t {
+		word.setBit(i)
+	}
+	word.p, word.j = word.t, 0
+}
 
-	r2 := aux[0].Lsh(bigOne, s+t)
-	r3 := aux[1].Lsh(bigOne, t)
-	r3.Sub(r3, bigOne)
-	return ComputerWordBig{
-		r2: r2,
-		r3: r3,
-		r0: &aux[2],
-		r1: &aux[3],
+// newComputerWordBigAt initializes the algorithm for n elements, positioned at the combination of the specified
+// elements, in ascending order.
+// Precondition: `len(elements)>0`.
+func newComputerWordBigAt(n uint, elements []uint) ComputerWordBig {
+	t := uint(len(elements))
+	word := ComputerWordBig{
+		r3:    new(big.Int),
+		words: make([]big.Word, (n+bits.UintSize-1)/bits.UintSize),
+		n:     n,
+		t:     t,
+	}
+	for _, element := range elements {
+		word.setBit(element)
+	}
+	for word.p < t && elements[word.p] == word.p {
+		word.p++
 	}
+	if word.p < t {
+		word.j = elements[word.p]
+	}
+	return word
 }
 
 // newComputerWordBigEnded initializes the algorithm such that it does not yield any combinations.
 // In other words alg.hasNext() returns false.
 func newComputerWordBigEnded() ComputerWordBig {
-	return ComputerWordBig{
-		// anything such that r2&r3 != 0
-		r2: bigOne,
-		r3: bigOne,
-		r0: new(big.Int), // r0 is used by hasNext
-	}
+	return ComputerWordBig{r3: new(big.Int)}
 }
 
 // Elements returns an iterator over the elements selected for the current combination.
 func (word *ComputerWordBig) Elements() Elements {
 	return func(yield func(uint) bool) {
-		r0 := word.r0.Set(word.r3)
-		r1 := word.r1
-		for len(r0.Bits()) != 0 {
-			ntz := r0.TrailingZeroBits()
-			if !yield(ntz) {
-				break
+		for i, w := range word.words[:word.top] {
+			for ; w != 0; w &= w - 1 { // clear the rightmost 1-bit
+				if !yield(uint(i*bits.UintSize + bits.TrailingZeros(uint(w)))) {
+					return
+				}
 			}
-			r1.Sub(r0, bigOne) // here and below: clear r0's rightmost set bit
-			r0.And(r0, r1)
 		}
 	}
 }
@@ -110,6 +190,12 @@ func (word *ComputerWordBig) Elements() Elements {
 // Combinations returns an iterator over the generated combinations.
 func (word *ComputerWordBig) Combinations() Combinations {
 	return func(yield func(Elements) bool) {
+		if word.stop != nil || word.cycle != nil {
+			for word.current() && yield(word.Elements()) {
+				word.next()
+			}
+			return
+		}
 		for word.hasNext() && yield(word.Elements()) {
 			word.next()
 		}
@@ -125,7 +211,13 @@ func (word *ComputerWordBig) Combinations() Combinations {
 // used for bit-reading.
 func (word *ComputerWordBig) Words() iter.Seq[*big.Int] {
 	return func(yield func(*big.Int) bool) {
-		for word.hasNext() && yield(word.r3) {
+		if word.stop != nil || word.cycle != nil {
+			for word.current() && yield(word.r3.SetBits(word.words[:word.top])) {
+				word.next()
+			}
+			return
+		}
+		for word.hasNext() && yield(word.r3.SetBits(word.words[:word.top])) {
 			word.next()
 		}
 	}
@@ -148,3 +240,126 @@ func NewComputerWordBig(n, k uint) (ComputerWordBig, error) {
 	}
 	return newComputerWordBig(n-k, k), nil
 }
+
+// NewComputerWordBigAt returns a combinations generator, see NewComputerWordBig, positioned at the combination at
+// position `rank` in Cool-lex order. The generator yields that combination first.
+//
+// It is an error to pass arguments such that n < k, k = 0, or `rank` is not in the range [0, C(n,k)).
+func NewComputerWordBigAt(n, k uint, rank *big.Int) (ComputerWordBig, error) {
+	elements, err := UnrankBig(rank, n, k)
+	if err != nil {
+		return ComputerWordBig{}, err
+	}
+	return newComputerWordBigAt(n, elements), nil
+}
+
+// current reports whether the generator is positioned at a combination yet to be yielded, within its limits,
+// if any
+func (word *ComputerWordBig) current() bool {
+	if word.j >= word.n {
+		return word.wrap()
+	}
+	return word.stop == nil || !word.at(word.stop)
+}
+
+func (word *ComputerWordBig) step() {
+	word.next()
+}
+
+func (word *ComputerWordBig) size() uint {
+	return word.n
+}
+
+func (word *ComputerWordBig) reposition(elements []uint) {
+	stop, cycle := word.stop, word.cycle
+	*word = newComputerWordBigAt(word.n, elements)
+	word.stop, word.cycle = stop, cycle
+}
+
+func (word *ComputerWordBig) end() {
+	word.j = word.n
+	word.cycle = nil
+}
+
+// Advance skips `m` combinations, see ComputerWord64.Advance.
+func (word *ComputerWordBig) Advance(m uint64) {
+	advance(word, m)
+}
+
+// AdvanceBig skips `m` combinations, see ComputerWord64.Advance.
+//
+// It is an error to pass a negative `m`.
+func (word *ComputerWordBig) AdvanceBig(m *big.Int) error {
+	return advanceBig(word, m)
+}
+
+// Stride returns an iterator over every m-th generated combination, see ComputerWord64.Stride.
+func (word *ComputerWordBig) Stride(m uint64) Combinations {
+	return stride(word, m)
+}
+
+func (word *ComputerWordBig) limits() ([]uint, bool) {
+	switch {
+	case word.cycle != nil:
+		return slices.Collect(word.cycle.Elements()), true
+	case word.stop != nil:
+		return slices.Collect(word.stop.Elements()), false
+	}
+	return nil, false
+}
+
+// Count returns the number of combinations that the generator is yet to yield, see ComputerWord64.Count.
+func (word *ComputerWordBig) Count() *big.Int {
+	return remaining(word)
+}
+
+// NewComputerWordBigRange returns a combinations generator, see NewComputerWordBig, that yields the
+// combinations at positions in the interval [start, end) in Cool-lex order.
+//
+// It is an error to pass arguments such that n < k, k = 0, or the interval is out of range [0, C(n,k)].
+func NewComputerWordBigRange(n, k uint, start, end *big.Int) (ComputerWordBig, error) {
+	first, stop, err := interval(n, k, start, end)
+	if err != nil {
+		return ComputerWordBig{}, err
+	}
+	if first == nil {
+		return newComputerWordBigEnded(), nil
+	}
+	word := newComputerWordBigAt(n, first)
+	if stop != nil {
+		end := newComputerWordBigAt(n, stop)
+		word.stop = &end
+	}
+	return word, nil
+}
+
+// NewComputerWordBigBetween returns a combinations generator, see NewComputerWordBig, that yields the
+// combinations from the combination of the elements `start`, inclusive, to the combination of the elements
+// `end`, exclusive, see NewComputerWord64Between.
+//
+// It is an error to pass arguments such that the combinations are of different or no elements, out-of-range
+// elements, or `end` precedes `start`.
+func NewComputerWordBigBetween(n uint, start, end []uint) (ComputerWordBig, error) {
+	startRank, endRank, err := intervalRanks(n, start, end)
+	if err != nil {
+		return ComputerWordBig{}, err
+	}
+	return NewComputerWordBigRange(n, uint(len(start)), startRank, endRank)
+}
+
+// NewComputerWordBigCyclic returns a combinations generator, see NewComputerWordBig, that yields all the C(n,k)
+// combinations in Cool-lex order, starting at the combination at position `rank`, see NewComputerWord64Cyclic.
+//
+// It is an error to pass arguments such that n < k, k = 0, or `rank` is not in the range [0, C(n,k)).
+func NewComputerWordBigCyclic(n, k uint, rank *big.Int) (ComputerWordBig, error) {
+	elements, err := UnrankBig(rank, n, k)
+	if err != nil {
+		return ComputerWordBig{}, err
+	}
+	word := newComputerWordBigAt(n, elements)
+	if rank.Sign() > 0 {
+		start := newComputerWordBigAt(n, elements)
+		word.cycle = &start
+	}
+	return word, nil
+}
@@ -12,6 +12,7 @@
 package coollex
 
 import (
+	"math/big"
 	"testing"
 )
 
@@ -30,3 +31,11 @@ func TestComputerWordBig(t *testing.T) {
 		return &w, err
 	})
 }
+
+// TestComputerWordBigWords verifies the order of the combinations as yielded by Words.
+func TestComputerWordBigWords(t *testing.T) {
+	testOrder(t, func(n, k uint) (coollexAlgorithm, error) {
+		word, err := NewComputerWordBig(n, k)
+		return wordGenerator[*big.Int]{word.Words(), bigElements}, err
+	}, largeCases...)
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"fmt"
+	"iter"
+	"math/big"
+	"math/bits"
+)
+
+// Limbs is the set of fixed-size "registers" supported by ComputerWordN. A register is an array of `uint64`
+// limbs in little-endian order, that is, limb 0 stores the 64 least-significant bits.
+type Limbs interface {
+	[2]uint64 | [4]uint64 | [8]uint64
+}
+
+// ComputerWordN implements the register-based (computer words) algorithm from the paper,
+// see 3.3. Implementation in Computer Words, page 10.
+// The implementation here is based on fixed-size multiword registers, allowing for `n<64*len(W)`.
+type ComputerWordN[W Limbs] struct {
+	r2, r3 W // names as in the paper; r2 is mask, r3 stores the combination
+}
+
+type (
+	// ComputerWord128 is a ComputerWordN with 128-bit registers, allowing for `n<=127`.
+	ComputerWord128 = ComputerWordN[[2]uint64]
+	// ComputerWord256 is a ComputerWordN with 256-bit registers, allowing for `n<=255`.
+	ComputerWord256 = ComputerWordN[[4]uint64]
+	// ComputerWord512 is a ComputerWordN with 512-bit registers, allowing for `n<=511`.
+	ComputerWord512 = ComputerWordN[[8]uint64]
+)
+
+// hasNext reports whether more combinations are available
+func (word *ComputerWordN[W]) hasNext() bool {
+	for i := range len(word.r3) {
+		if word.r3[i]&word.r2[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// next advances to the next combination in cool-lex order
+func (word *ComputerWordN[W]) next() {
+	r3 := word.r3
+	var r0, r1 W
+
+	// r0 := r3 & (r3 + 1)
+	carry := uint64(1)
+	for i := range len(r3) {
+		r0[i], carry = bits.Add64(r3[i], 0, carry)
+		r0[i] &= r3[i]
+	}
+	// r1 := r0 ^ (r0 - 1)
+	borrow := uint64(1)
+	for i := range len(r0) {
+		r1[i], borrow = bits.Sub64(r0[i], 0, borrow)
+		r1[i] ^= r0[i]
+	}
+	// r0 = r1 + 1; r1 = r1 & r3; r0 = r0 & r3
+	carry = 1
+	nonZero := false
+	for i := range len(r1) {
+		r0[i], carry = bits.Add64(r1[i], 0, carry)
+		r0[i] &= r3[i]
+		r1[i] &= r3[i]
+		nonZero = nonZero || r0[i] != 0
+	}
+	// r0 = DOZ(r0, 1)
+	if nonZero {
+		borrow = 1
+		for i := range len(r0) {
+			r0[i], borrow = bits.Sub64(r0[i], 0, borrow)
+		}
+	}
+	// r3 = r3 + r1 - r0
+	carry, borrow = 0, 0
+	for i := range len(r3) {
+		r3[i], carry = bits.Add64(r3[i], r1[i], carry)
+		r3[i], borrow = bits.Sub64(r3[i], r0[i], borrow)
+	}
+	word.r3 = r3
+}
+
+func elementsN[W Limbs](v W) Elements {
+	return func(yield func(uint) bool) {
+		for i := range len(v) {
+			for r := v[i]; r != 0; r &= r - 1 { // clear the rightmost 1-bit
+				ntz := bits.TrailingZeros64(r)
+				if !yield(uint(i*64 + ntz)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// newComputerWordN initializes the algorithm for the specified number of 0-bits (s) and number of 1-bits (t).
+// Precondition: `t>0` and `s+t<64*len(W)`.
+func newComputerWordN[W Limbs](s, t uint) ComputerWordN[W] {
+	var word ComputerWordN[W]
+	word.r2[(s+t)/64] = 1 << ((s + t) % 64)
+	for i := uint(0); t > 0; i++ {
+		ones := min(t, 64)
+		word.r3[i] = (1<<(ones-1))<<1 - 1 // avoids shifting by 64
+		t -= ones
+	}
+	return word
+}
+
+// Elements returns an iterator over the elements selected for the current combination.
+func (word *ComputerWordN[W]) Elements() Elements {
+	return elementsN(word.r3)
+}
+
+// Combinations returns an iterator over the generated combinations.
+func (word *ComputerWordN[W]) Combinations() Combinations {
+	return func(yield func(Elements) bool) {
+		for word.hasNext() && yield(word.Elements()) {
+			word.next()
+		}
+	}
+}
+
+// Words returns an iterator over the generated combinations as follows:
+//   - a combination is represented by an array of `uint64` limbs, limb 0 storing the least-significant bits
+//   - in a combination, bits that are set represent the elements selected for the combination
+//   - the `n` least-significant bits store the combination, with `k` bits set; the other
+//     most-significant bits are cleared
+func (word *ComputerWordN[W]) Words() iter.Seq[W] {
+	return func(yield func(W) bool) {
+		for word.hasNext() && yield(word.r3) {
+			word.next()
+		}
+	}
+}
+
+// NewComputerWordN returns a combinations generator that yields combinations in Cool-lex order, working
+// internally with multiword "registers" of type W.
+//
+// n: number of elements to combine; n>=k and n<64*len(W) must hold.
+//
+// k: number of elements in each combination.
+//
+// It is an error to pass arguments such that n < k.
+// It is an error to pass arguments such that n >= 64*len(W).
+func NewComputerWordN[W Limbs](n, k uint) (ComputerWordN[W], error) {
+	if n < k {
+		return ComputerWordN[W]{}, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	var word ComputerWordN[W]
+	if limit := uint(64 * len(word.r3)); n >= limit {
+		return ComputerWordN[W]{}, fmt.Errorf("n (%d) greater than %d, consider using ComputerWordBig", n, limit-1)
+	}
+	if k == 0 {
+		word.r2[0], word.r3[0] = 1, 1 // anything such that r2&r3 != 0
+		return word, nil
+	}
+	return newComputerWordN[W](n-k, k), nil
+}
+
+func (word *ComputerWordN[W]) current() bool {
+	return word.hasNext()
+}
+
+func (word *ComputerWordN[W]) step() {
+	word.next()
+}
+
+func (word *ComputerWordN[W]) size() uint {
+	for i := range len(word.r2) {
+		if word.r2[i] != 0 {
+			return uint(i*64 + bits.TrailingZeros64(word.r2[i]))
+		}
+	}
+	return 0
+}
+
+func (word *ComputerWordN[W]) reposition(elements []uint) {
+	var r3 W
+	for _, element := range elements {
+		r3[element/64] |= 1 << (element % 64)
+	}
+	word.r3 = r3
+}
+
+func (word *ComputerWordN[W]) end() {
+	word.r3 = word.r2
+}
+
+// Advance skips `m` combinations, see ComputerWord64.Advance.
+func (word *ComputerWordN[W]) Advance(m uint64) {
+	advance(word, m)
+}
+
+// AdvanceBig skips `m` combinations, see ComputerWord64.Advance.
+//
+// It is an error to pass a negative `m`.
+func (word *ComputerWordN[W]) AdvanceBig(m *big.Int) error {
+	return advanceBig(word, m)
+}
+
+// Stride returns an iterator over every m-th generated combination, see ComputerWord64.Stride.
+func (word *ComputerWordN[W]) Stride(m uint64) Combinations {
+	return stride(word, m)
+}
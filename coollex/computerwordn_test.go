@@ -0,0 +1,66 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"math/big"
+	"testing"
+)
+
+func BenchmarkComputerWord128(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		w, _ := NewComputerWordN[[2]uint64](benchAlgorithmN, benchAlgorithmK)
+		for w.hasNext() {
+			w.next()
+		}
+	}
+}
+
+func TestComputerWordN(t *testing.T) {
+	testCoollex(t, func(n, k uint) (coollexAlgorithm, error) {
+		w, err := NewComputerWordN[[2]uint64](n, k)
+		return &w, err
+	})
+	testCoollex(t, func(n, k uint) (coollexAlgorithm, error) {
+		w, err := NewComputerWordN[[4]uint64](n, k)
+		return &w, err
+	})
+	testCoollex(t, func(n, k uint) (coollexAlgorithm, error) {
+		w, err := NewComputerWordN[[8]uint64](n, k)
+		return &w, err
+	})
+
+	if _, err := NewComputerWordN[[2]uint64](128, 1); err == nil {
+		t.Fatalf("error is expected for n>=128")
+	}
+	if _, err := NewComputerWordN[[8]uint64](511, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestComputerWordNWords verifies the order of the combinations as yielded by Words, spanning multiple limbs.
+func TestComputerWordNWords(t *testing.T) {
+	testOrder(t, func(n, k uint) (coollexAlgorithm, error) {
+		word, err := NewComputerWordN[[4]uint64](n, k)
+		return wordGenerator[[4]uint64]{word.Words(), func(limbs [4]uint64) Elements {
+			return bigElements(limbsToBig(limbs))
+		}}, err
+	}, largeCases...)
+}
+
+func limbsToBig[W Limbs](word W) *big.Int {
+	result, limb := new(big.Int), new(big.Int)
+	for i := len(word) - 1; i >= 0; i-- {
+		result.Lsh(result, 64).Or(result, limb.SetUint64(word[i]))
+	}
+	return result
+}
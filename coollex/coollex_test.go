@@ -12,9 +12,11 @@
 package coollex
 
 import (
-	"fmt"
-	"github.com/dastoikov/cool-lex-go/v2/simplemath"
+	"iter"
+	"math/big"
 	"testing"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex/coollextest"
 )
 
 const (
@@ -23,84 +25,57 @@ const (
 	benchAlgorithmK = 3
 )
 
-// verifyNoCombs verifies that `generator` does not yield combinations for `n` and `k`.
-// It returns an error if any are yielded.
-func verifyNoCombs(n, k uint, generator func(n, k uint) (coollexAlgorithm, error)) error {
-	alg, _ := generator(n, k)
-	for combination := range alg.Combinations() {
-		for element := range combination {
-			return fmt.Errorf("element %v found for n %v and k %v", element, n, k)
-		}
-		return fmt.Errorf("combinations found for n %v and k %v", n, k)
-	}
-	return nil
+// testCoollex runs the conformance suite for `generator`.
+func testCoollex(t *testing.T, generator func(n, k uint) (coollexAlgorithm, error)) {
+	t.Helper()
+	coollextest.Run(t, func(n, k uint) (coollextest.Generator, error) {
+		return generator(n, k)
+	})
 }
 
-// verifyCombs verifies that combinations yielded by `generator` for `n` and `k` have the following properties:
-//   - the number of combinations generated is correct;
-//   - the number of elements in each combination is correct;
-//   - the number of combinations where each element occurs is correct.
-//
-// It does not test combinations are yielded in Cool-lex order.
-// It returns an error if `generator` fails to yield combinations.
-func verifyCombs(n, k uint, generator func(n, k uint) (coollexAlgorithm, error)) error {
-	// array index denotes an element
-	// value at given index denotes how many times this element appeared in a combination
-	hits := make([]uint, n)
-
-	alg, err := generator(n, k)
-	if err != nil {
-		return err
-	}
+// largeCases are the cases, with n beyond those of coollextest.DefaultCases, for the generators allowing for
+// larger n
+var largeCases = []coollextest.Case{
+	{N: 1, K: 1}, {N: 5, K: 5}, {N: 12, K: 6}, {N: 40, K: 2}, {N: 40, K: 38},
+	{N: 70, K: 3}, {N: 70, K: 67}, {N: 130, K: 3}, {N: 200, K: 2},
+}
 
-	// total number of combinations yielded by the algorithm
-	numComb := uint(0)
-	for combination := range alg.Combinations() {
-		numComb++
-		// number of elements in this combination
-		numElem := uint(0)
-		for element := range combination {
-			numElem++
-			hits[element]++
-		}
-		if k != numElem {
-			return fmt.Errorf("number of elements in a combination: expected %d, got %d, for n %d", k, numElem, n)
+// testOrder verifies that `generator` yields the combinations in Cool-lex order for the cases.
+func testOrder(t *testing.T, generator func(n, k uint) (coollexAlgorithm, error), cases ...coollextest.Case) {
+	t.Helper()
+	for _, c := range cases {
+		err := coollextest.VerifyOrder(c.N, c.K, func(n, k uint) (coollextest.Generator, error) {
+			return generator(n, k)
+		})
+		if err != nil {
+			t.Fatal(err)
 		}
 	}
-	expectedNumComb, err := simplemath.NumComb(n, k)
-	if err != nil {
-		return err
-	}
-	if numComb != expectedNumComb {
-		return fmt.Errorf("number of combinations: expected %d, got %d, for n %d and k %d", expectedNumComb, numComb, n, k)
-	}
-	occur, err := simplemath.NumComb(n-1, k-1)
-	if err != nil {
-		return err
-	}
-	for element, hit := range hits {
-		if occur != hit {
-			return fmt.Errorf("number of combinations where each element appears: expected %d, got %d, for element %d, n %d, and k %d ", occur, hit, element, n, k)
+}
+
+// wordGenerator adapts an iterator over words, as that of Words or Bits, to the combinations of their elements
+type wordGenerator[W any] struct {
+	words    iter.Seq[W]
+	elements func(W) Elements
+}
+
+func (g wordGenerator[W]) Combinations() Combinations {
+	return func(yield func(Elements) bool) {
+		for word := range g.words {
+			if !yield(g.elements(word)) {
+				return
+			}
 		}
 	}
-	return nil
 }
-func testCoollex(t *testing.T, generator func(n, k uint) (coollexAlgorithm, error)) {
-	testCases := []struct {
-		n, k uint
-		test func(n, k uint, generator func(n, k uint) (coollexAlgorithm, error)) error
-	}{
-		{15, 8, verifyCombs},
-		{15, 7, verifyCombs},
-		{9, 9, verifyCombs},
-		{1, 1, verifyCombs},
-		{9, 0, verifyNoCombs},
-		{2, 0, verifyNoCombs},
-	}
-	for _, tc := range testCases {
-		err := tc.test(tc.n, tc.k, generator)
-		if err != nil {
-			t.Fatal(err)
+
+// bigElements returns the elements of a word as in `ComputerWordBig.Words()`
+func bigElements(word *big.Int) Elements {
+	return func(yield func(uint) bool) {
+		for i := range word.BitLen() {
+			if word.Bit(i) == 1 && !yield(uint(i)) {
+				return
+			}
 		}
 	}
 }
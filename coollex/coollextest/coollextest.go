@@ -0,0 +1,257 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package coollextest implements a conformance suite for Cool-lex combinations generators, that is, for
+implementations of the `Combinations()` API of the coollex package algorithms.
+
+A conforming generator, created for `n` and `k`:
+  - yields exactly C(n,k) combinations, in Cool-lex order, for k>0; and none for k=0;
+  - yields, for each combination, k distinct elements in the range [0, n), in ascending order;
+  - stops when `yield` returns false, whether iterating over combinations or over elements;
+  - resumes, when `Combinations()` is called again, at the combination for which `yield` returned false;
+  - yields no combinations once all have been yielded.
+
+Its constructor reports an error for n<k.
+
+Usage:
+
+	func TestMyGenerator(t *testing.T) {
+		coollextest.Run(t, func(n, k uint) (coollextest.Generator, error) {
+			g, err := NewMyGenerator(n, k)
+			return &g, err
+		})
+	}
+*/
+package coollextest
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex/reference"
+	"github.com/dastoikov/cool-lex-go/v2/simplemath"
+)
+
+// Generator is the API under test. It is implemented by the coollex package algorithms.
+type Generator interface {
+	Combinations() iter.Seq[iter.Seq[uint]]
+}
+
+// NewGenerator creates a Generator for n and k.
+type NewGenerator func(n, k uint) (Generator, error)
+
+// Case specifies the arguments that a generator is tested with.
+type Case struct {
+	N, K uint
+}
+
+// DefaultCases are the cases used by Run. They are suitable for generators allowing for `n<=31`.
+var DefaultCases = []Case{
+	{15, 8}, {15, 7}, {12, 5}, {9, 9}, {9, 1}, {2, 1}, {1, 1}, {9, 0}, {2, 0},
+}
+
+// VerifyCount verifies that the generator yields exactly C(n,k) combinations for k>0, and none for k=0.
+func VerifyCount(n, k uint, newGenerator NewGenerator) error {
+	generator, err := newGenerator(n, k)
+	if err != nil {
+		return err
+	}
+	expect := uint(0)
+	if k > 0 {
+		if expect, err = simplemath.NumComb(n, k); err != nil {
+			return err
+		}
+	}
+	actual := uint(0)
+	for range generator.Combinations() {
+		actual++
+	}
+	if actual != expect {
+		return fmt.Errorf("number of combinations: expected %d, got %d, for n %d and k %d", expect, actual, n, k)
+	}
+	return nil
+}
+
+// VerifyElements verifies that each combination consists of k elements, in ascending order, in the range
+// [0, n), and that each element occurs in exactly C(n-1,k-1) combinations.
+func VerifyElements(n, k uint, newGenerator NewGenerator) error {
+	generator, err := newGenerator(n, k)
+	if err != nil {
+		return err
+	}
+	// array index denotes an element
+	// value at given index denotes how many times this element appeared in a combination
+	hits := make([]uint, n)
+	for combination := range generator.Combinations() {
+		numElem, prev := uint(0), uint(0)
+		for element := range combination {
+			if element >= n {
+				return fmt.Errorf("element %d out of range [0, %d), for k %d", element, n, k)
+			}
+			if numElem > 0 && element <= prev {
+				return fmt.Errorf("element %d not in ascending order after %d, for n %d and k %d", element, prev, n, k)
+			}
+			hits[element]++
+			numElem++
+			prev = element
+		}
+		if numElem != k {
+			return fmt.Errorf("number of elements in a combination: expected %d, got %d, for n %d", k, numElem, n)
+		}
+	}
+	if k == 0 {
+		return nil
+	}
+	occur, err := simplemath.NumComb(n-1, k-1)
+	if err != nil {
+		return err
+	}
+	for element, hit := range hits {
+		if occur != hit {
+			return fmt.Errorf("number of combinations where each element appears: expected %d, got %d, for element %d, n %d, and k %d", occur, hit, element, n, k)
+		}
+	}
+	return nil
+}
+
+// VerifyOrder verifies that the generator yields the combinations in Cool-lex order, as defined by the
+// reference implementation.
+func VerifyOrder(n, k uint, newGenerator NewGenerator) error {
+	generator, err := newGenerator(n, k)
+	if err != nil {
+		return err
+	}
+	return reference.Compare(n, k, generator.Combinations())
+}
+
+// VerifyBreak verifies that the generator stops when `yield` returns false, while iterating over
+// combinations and while iterating over elements.
+func VerifyBreak(n, k uint, newGenerator NewGenerator) error {
+	generator, err := newGenerator(n, k)
+	if err != nil {
+		return err
+	}
+	calls := 0
+	var first iter.Seq[uint]
+	generator.Combinations()(func(combination iter.Seq[uint]) bool {
+		calls++
+		first = combination
+		return false
+	})
+	if calls > 1 {
+		return fmt.Errorf("yield called %d times over combinations, after returning false, for n %d and k %d", calls-1, n, k)
+	}
+	if first == nil {
+		return nil
+	}
+	calls = 0
+	first(func(uint) bool {
+		calls++
+		return false
+	})
+	if calls > 1 {
+		return fmt.Errorf("yield called %d times over elements, after returning false, for n %d and k %d", calls-1, n, k)
+	}
+	return nil
+}
+
+// VerifyRestart verifies that, when `Combinations()` is called again, the generator resumes at the combination
+// for which `yield` returned false, and that it yields no combinations once all have been yielded.
+func VerifyRestart(n, k uint, newGenerator NewGenerator) error {
+	generator, err := newGenerator(n, k)
+	if err != nil {
+		return err
+	}
+	expect := slices.Collect(func(yield func([]uint) bool) {
+		for combination := range reference.Combinations(n, k) {
+			if !yield(slices.Clone(combination)) {
+				return
+			}
+		}
+	})
+
+	// stop at every third combination, and resume
+	for start := 0; ; {
+		i, stopped := start, false
+		for combination := range generator.Combinations() {
+			actual := slices.Collect(combination)
+			if i >= len(expect) {
+				return fmt.Errorf("combination %d: unexpected %v, for n %d and k %d", i, actual, n, k)
+			}
+			if !slices.Equal(expect[i], actual) {
+				return fmt.Errorf("combination %d: expected %v, got %v, upon resumption, for n %d and k %d", i, expect[i], actual, n, k)
+			}
+			if i > start && i%3 == 0 {
+				stopped = true
+				break
+			}
+			i++
+		}
+		if !stopped {
+			if i != len(expect) {
+				return fmt.Errorf("number of combinations: expected %d, got %d, upon resumption, for n %d and k %d", len(expect), i, n, k)
+			}
+			break
+		}
+		start = i // the combination to resume at
+	}
+	for combination := range generator.Combinations() {
+		return fmt.Errorf("combination %v found after the last one, for n %d and k %d", slices.Collect(combination), n, k)
+	}
+	return nil
+}
+
+// VerifyInvalid verifies that the generator cannot be created for n<k.
+func VerifyInvalid(newGenerator NewGenerator) error {
+	if _, err := newGenerator(2, 3); err == nil {
+		return fmt.Errorf("error is expected for n (2) less than k (3)")
+	}
+	return nil
+}
+
+// Run runs the conformance suite for DefaultCases, see RunCases.
+func Run(t *testing.T, newGenerator NewGenerator) {
+	t.Helper()
+	RunCases(t, newGenerator, DefaultCases...)
+}
+
+// RunCases runs the conformance suite for the specified cases, each verification as a subtest of t.
+func RunCases(t *testing.T, newGenerator NewGenerator, cases ...Case) {
+	t.Helper()
+	verifications := []struct {
+		name   string
+		verify func(n, k uint, newGenerator NewGenerator) error
+	}{
+		{"Count", VerifyCount},
+		{"Elements", VerifyElements},
+		{"Order", VerifyOrder},
+		{"Break", VerifyBreak},
+		{"Restart", VerifyRestart},
+	}
+	for _, v := range verifications {
+		t.Run(v.name, func(t *testing.T) {
+			for _, c := range cases {
+				if err := v.verify(c.N, c.K, newGenerator); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+	t.Run("Invalid", func(t *testing.T) {
+		if err := VerifyInvalid(newGenerator); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
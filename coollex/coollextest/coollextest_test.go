@@ -0,0 +1,108 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollextest
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex/reference"
+)
+
+// sliceGenerator yields precomputed combinations
+type sliceGenerator struct {
+	combinations [][]uint
+	next         int
+	skipOnResume bool // resume after, rather than at, the combination that iteration stopped at
+	ignoreStop   bool // keep yielding once `yield` returned false
+}
+
+// values yields the elements, stopping when `yield` returns false, unless ignoreStop
+func (g *sliceGenerator) values(combination []uint) iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for _, element := range combination {
+			if !yield(element) && !g.ignoreStop {
+				return
+			}
+		}
+	}
+}
+
+func (g *sliceGenerator) Combinations() iter.Seq[iter.Seq[uint]] {
+	return func(yield func(iter.Seq[uint]) bool) {
+		for g.next < len(g.combinations) {
+			combination := g.combinations[g.next]
+			if g.skipOnResume {
+				g.next++
+			}
+			if !yield(g.values(combination)) && !g.ignoreStop {
+				return
+			}
+			if !g.skipOnResume {
+				g.next++
+			}
+		}
+	}
+}
+
+// newSliceGenerator returns a generator of the reference combinations, modified by `modify`
+func newSliceGenerator(modify func(g *sliceGenerator)) NewGenerator {
+	return func(n, k uint) (Generator, error) {
+		if n < k {
+			return nil, fmt.Errorf("n (%d) less than k (%d)", n, k)
+		}
+		g := &sliceGenerator{}
+		for combination := range reference.Combinations(n, k) {
+			g.combinations = append(g.combinations, slices.Clone(combination))
+		}
+		modify(g)
+		return g, nil
+	}
+}
+
+func TestConforming(t *testing.T) {
+	Run(t, newSliceGenerator(func(g *sliceGenerator) {}))
+}
+
+func TestNonConforming(t *testing.T) {
+	testCases := []struct {
+		name         string
+		verify       func(n, k uint, newGenerator NewGenerator) error
+		newGenerator NewGenerator
+	}{
+		{"Count", VerifyCount, newSliceGenerator(func(g *sliceGenerator) {
+			g.combinations = g.combinations[1:]
+		})},
+		{"Elements", VerifyElements, newSliceGenerator(func(g *sliceGenerator) {
+			g.combinations[0] = []uint{1, 0, 2, 3, 4}
+		})},
+		{"Order", VerifyOrder, newSliceGenerator(func(g *sliceGenerator) {
+			slices.Reverse(g.combinations)
+		})},
+		{"Break", VerifyBreak, newSliceGenerator(func(g *sliceGenerator) {
+			g.ignoreStop = true
+		})},
+		{"Restart", VerifyRestart, newSliceGenerator(func(g *sliceGenerator) {
+			g.skipOnResume = true
+		})},
+	}
+	for _, tc := range testCases {
+		if err := tc.verify(9, 5, tc.newGenerator); err == nil {
+			t.Fatalf("%s: error is expected", tc.name)
+		}
+	}
+	if err := VerifyInvalid(func(n, k uint) (Generator, error) { return &sliceGenerator{}, nil }); err == nil {
+		t.Fatal("Invalid: error is expected")
+	}
+}
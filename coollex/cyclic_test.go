@@ -0,0 +1,195 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+var cyclics = map[string]func(n, k, rank uint) (bounded, error){
+	"ComputerWord32": func(n, k, rank uint) (bounded, error) {
+		g, err := NewComputerWord32Cyclic(n, k, rank)
+		return bounded64{&g, g.Count}, err
+	},
+	"ComputerWord64": func(n, k, rank uint) (bounded, error) {
+		g, err := NewComputerWord64Cyclic(n, k, rank)
+		return bounded64{&g, g.Count}, err
+	},
+	"ComputerWordBig": func(n, k, rank uint) (bounded, error) {
+		g, err := NewComputerWordBigCyclic(n, k, big.NewInt(int64(rank)))
+		return boundedBig{&g, g.Count}, err
+	},
+	"LinkedList": func(n, k, rank uint) (bounded, error) {
+		g, err := NewLinkedListCyclic(n, k, big.NewInt(int64(rank)))
+		return boundedBig{&g, g.Count}, err
+	},
+}
+
+// rotated returns the combinations of the generator in Cool-lex order, starting at the one at `rank`
+func rotated(n, k, rank uint) [][]uint {
+	g, _ := NewComputerWord64(n, k)
+	all := collect(g.Combinations())
+	return append(all[rank:], all[:rank]...)
+}
+
+func TestCyclic(t *testing.T) {
+	for _, tc := range []struct{ n, k uint }{{1, 1}, {5, 5}, {9, 1}, {10, 4}, {12, 6}, {31, 1}, {31, 30}} {
+		size := uint(binomial(tc.n, tc.k).Uint64())
+		for name, newCyclic := range cyclics {
+			for _, rank := range []uint{0, 1, size / 3, size - 1} {
+				if rank >= size {
+					continue
+				}
+				g, err := newCyclic(tc.n, tc.k, rank)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if count := g.count(); count.Cmp(big.NewInt(int64(size))) != 0 {
+					t.Fatalf("%s, n=%d, k=%d: expected count %d, got %v", name, tc.n, tc.k, size, count)
+				}
+				expect := rotated(tc.n, tc.k, rank)
+				if actual := collect(g.Combinations()); !slices.EqualFunc(actual, expect, slices.Equal) {
+					t.Fatalf("%s, n=%d, k=%d, from %d: expected %v, got %v", name, tc.n, tc.k, rank, expect, actual)
+				}
+				if count := g.count(); count.Sign() != 0 {
+					t.Fatalf("%s: expected count 0 once ended, got %v", name, count)
+				}
+			}
+		}
+	}
+}
+
+func TestCyclicWords(t *testing.T) {
+	const n, k, rank = 63, 2, 1000
+	g, _ := NewComputerWord64Cyclic(n, k, rank)
+	var expect []int64
+	for _, combination := range rotated(n, k, rank) {
+		expect = append(expect, toWord64(combination))
+	}
+	if actual := slices.Collect(g.Words()); !slices.Equal(actual, expect) {
+		t.Fatalf("expected %d words, got %d", len(expect), len(actual))
+	}
+	g32, _ := NewComputerWord32Cyclic(31, 3, 4000)
+	count := 0
+	for word := range g32.Words() {
+		if word < 0 || word>>31 != 0 {
+			t.Fatalf("unexpected word %#x", word)
+		}
+		count++
+	}
+	if count != 4495 {
+		t.Fatalf("expected 4495 words, got %d", count)
+	}
+}
+
+func TestCyclicResume(t *testing.T) {
+	const n, k, rank = 10, 4, 150
+	all := rotated(n, k, rank)
+	for name, newCyclic := range cyclics {
+		g, _ := newCyclic(n, k, rank)
+		i := 0
+		for range g.Combinations() {
+			if i == 100 {
+				break
+			}
+			i++
+		}
+		if actual := collect(g.Combinations()); !slices.EqualFunc(actual, all[100:], slices.Equal) {
+			t.Fatalf("%s: expected %d combinations, got %d", name, len(all)-100, len(actual))
+		}
+	}
+}
+
+func TestCyclicAdvance(t *testing.T) {
+	const n, k, rank = 13, 6, 1500
+	all := rotated(n, k, rank)
+	for name, newCyclic := range cyclics {
+		for _, m := range []uint64{0, 3, 64, 65, 200, 900, uint64(len(all) - 1), uint64(len(all)), 1 << 40} {
+			g, _ := newCyclic(n, k, rank)
+			g.Advance(m)
+			expect := all[min(m, uint64(len(all))):]
+			if actual := collect(g.Combinations()); !slices.EqualFunc(actual, expect, slices.Equal) {
+				t.Fatalf("%s: advanced by %d: expected %d combinations, got %d", name, m, len(expect), len(actual))
+			}
+		}
+		for _, m := range []uint64{1, 2, 7, 100} {
+			var expect [][]uint
+			for i := 0; i < len(all); i += int(m) {
+				expect = append(expect, all[i])
+			}
+			g, _ := newCyclic(n, k, rank)
+			if actual := collect(g.Stride(m)); !slices.EqualFunc(actual, expect, slices.Equal) {
+				t.Fatalf("%s: stride %d: expected %d combinations, got %d", name, m, len(expect), len(actual))
+			}
+		}
+	}
+}
+
+func TestCyclicErrors(t *testing.T) {
+	for name, newCyclic := range cyclics {
+		for _, tc := range []struct{ n, k, rank uint }{{3, 4, 0}, {5, 0, 0}, {5, 2, 10}} {
+			if _, err := newCyclic(tc.n, tc.k, tc.rank); err == nil {
+				t.Fatalf("%s: error is expected for %+v", name, tc)
+			}
+		}
+	}
+}
+
+func TestCyclicLarge(t *testing.T) {
+	// cycles of more than 2^64-1 combinations
+	for _, tc := range []struct{ n, k uint }{{68, 34}, {200, 100}} {
+		count := binomial(tc.n, tc.k)
+		rank := new(big.Int).Sub(count, big.NewInt(2))
+		var expect [][]uint
+		for _, r := range []*big.Int{rank, new(big.Int).Add(rank, bigOne), new(big.Int), bigOne} {
+			elements, _ := UnrankBig(r, tc.n, tc.k)
+			expect = append(expect, elements)
+		}
+		newCyclic := map[string]func() bounded{
+			"ComputerWordBig": func() bounded {
+				g, _ := NewComputerWordBigCyclic(tc.n, tc.k, rank)
+				return boundedBig{&g, g.Count}
+			},
+			"LinkedList": func() bounded {
+				g, _ := NewLinkedListCyclic(tc.n, tc.k, rank)
+				return boundedBig{&g, g.Count}
+			},
+		}
+		for name, newCyclic := range newCyclic {
+			g := newCyclic()
+			if g.count().Cmp(count) != 0 {
+				t.Fatalf("%s, n=%d, k=%d: expected %v combinations, got %v", name, tc.n, tc.k, count, g.count())
+			}
+			var actual [][]uint
+			for combination := range g.Combinations() {
+				if actual = append(actual, slices.Collect(combination)); len(actual) == len(expect) {
+					break
+				}
+			}
+			if !slices.EqualFunc(actual, expect, slices.Equal) {
+				t.Fatalf("%s, n=%d, k=%d: expected %v, got %v", name, tc.n, tc.k, expect, actual)
+			}
+
+			// to the combination preceding the starting one, past the first one
+			g = newCyclic()
+			if err := g.AdvanceBig(new(big.Int).Sub(count, bigOne)); err != nil {
+				t.Fatal(err)
+			}
+			last, _ := UnrankBig(new(big.Int).Sub(rank, bigOne), tc.n, tc.k)
+			if actual := collect(g.Combinations()); !slices.EqualFunc(actual, [][]uint{last}, slices.Equal) {
+				t.Fatalf("%s, n=%d, k=%d: expected %v, got %v", name, tc.n, tc.k, last, actual)
+			}
+		}
+	}
+}
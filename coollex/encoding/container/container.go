@@ -0,0 +1,206 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package container implements a self-describing binary format for streams of combinations, of k out of n
+elements, in Cool-lex order.
+
+A container consists of a header, a body and a trailer. All integers are in little-endian byte order.
+
+The header:
+
+	magic      8 bytes, "COOLLEX\x00"
+	version    uint16, 1
+	flags      uint16, bit 0 set if the trailer has a checksum
+	order      uint8, 1 for Cool-lex order
+	algorithm  uint8, the algorithm that generated the combinations, see Algorithm; informative only
+	reserved   uint16, 0
+	n          uint64
+	k          uint64
+	width      uint32, the number of bytes of a word, encoding.WordWidth(n)
+	rankLength uint32, the number of bytes of the starting rank
+	rank       rankLength bytes, the rank of the first combination in the body
+
+The body is a sequence of words, one per combination, each of `width` bytes, as in the Binary format of package
+encoding: the bit i is set for a selected element i.
+
+The trailer:
+
+	end        `width` bytes, 0; as k>0 for any combination, no word of the body is 0
+	count      uint64, the number of words in the body
+	checksum   uint32, optional, the CRC-32 (Castagnoli) of all the preceding bytes of the container
+
+Because the words are of fixed width, a Reader of a seekable container positions at a rank in constant time.
+*/
+package container
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math/big"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex/encoding"
+)
+
+// Magic identifies a container.
+const Magic = "COOLLEX\x00"
+
+// Version is the version of the format implemented by this package.
+const Version = 1
+
+const (
+	flagChecksum = 1 << 0
+	orderCoolLex = 1
+)
+
+// fixedHeaderSize is the size of the header, excluding the starting rank
+const fixedHeaderSize = 40
+
+// Algorithm identifies the algorithm that generated the combinations of a container.
+type Algorithm uint8
+
+// The algorithms.
+const (
+	AlgorithmUnspecified Algorithm = iota
+	AlgorithmComputerWord32
+	AlgorithmComputerWord64
+	AlgorithmComputerWordN
+	AlgorithmComputerWordBig
+	AlgorithmLinkedList
+	AlgorithmCompactLinkedList
+	AlgorithmSparse
+	AlgorithmArray
+)
+
+// Header describes a container.
+type Header struct {
+	// N and K are the number of elements to combine, and in each combination; K>0.
+	N, K uint
+	// Algorithm generated the combinations.
+	Algorithm Algorithm
+	// Start is the rank of the first combination; nil for 0.
+	Start *big.Int
+	// Checksum reports whether the trailer has a checksum.
+	Checksum bool
+}
+
+// WordWidth returns the number of bytes of a word, see encoding.WordWidth.
+func (h *Header) WordWidth() int {
+	return encoding.WordWidth(h.N)
+}
+
+func (h *Header) start() *big.Int {
+	if h.Start == nil {
+		return new(big.Int)
+	}
+	return h.Start
+}
+
+// marshal returns the binary representation of the header
+func (h *Header) marshal() ([]byte, error) {
+	if h.N < h.K {
+		return nil, fmt.Errorf("n (%d) less than k (%d)", h.N, h.K)
+	}
+	if h.K == 0 {
+		return nil, fmt.Errorf("no combinations for k=0")
+	}
+	start := h.start()
+	if start.Sign() < 0 {
+		return nil, fmt.Errorf("negative starting rank %v", start)
+	}
+	rank := start.Bytes() // big-endian
+	for i, j := 0, len(rank)-1; i < j; i, j = i+1, j-1 {
+		rank[i], rank[j] = rank[j], rank[i]
+	}
+	var flags uint16
+	if h.Checksum {
+		flags |= flagChecksum
+	}
+	b := make([]byte, 0, fixedHeaderSize+len(rank))
+	b = append(b, Magic...)
+	b = binary.LittleEndian.AppendUint16(b, Version)
+	b = binary.LittleEndian.AppendUint16(b, flags)
+	b = append(b, orderCoolLex, byte(h.Algorithm), 0, 0)
+	b = binary.LittleEndian.AppendUint64(b, uint64(h.N))
+	b = binary.LittleEndian.AppendUint64(b, uint64(h.K))
+	b = binary.LittleEndian.AppendUint32(b, uint32(h.WordWidth()))
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(rank)))
+	return append(b, rank...), nil
+}
+
+// readHeader reads the header, returning it and its size
+func readHeader(r io.Reader) (Header, int64, error) {
+	var fixed [fixedHeaderSize]byte
+	if _, err := io.ReadFull(r, fixed[:]); err != nil {
+		return Header{}, 0, fmt.Errorf("reading header: %w", err)
+	}
+	if string(fixed[:8]) != Magic {
+		return Header{}, 0, errors.New("not a container: invalid magic")
+	}
+	if version := binary.LittleEndian.Uint16(fixed[8:]); version != Version {
+		return Header{}, 0, fmt.Errorf("unsupported version %d", version)
+	}
+	flags := binary.LittleEndian.Uint16(fixed[10:])
+	if order := fixed[12]; order != orderCoolLex {
+		return Header{}, 0, fmt.Errorf("unsupported order %d", order)
+	}
+	n, k := binary.LittleEndian.Uint64(fixed[16:]), binary.LittleEndian.Uint64(fixed[24:])
+	h := Header{
+		N:         uint(n),
+		K:         uint(k),
+		Algorithm: Algorithm(fixed[13]),
+		Checksum:  flags&flagChecksum != 0,
+	}
+	if uint64(h.N) != n || uint64(h.K) != k || h.N < h.K || h.K == 0 {
+		return Header{}, 0, fmt.Errorf("invalid n (%d) and k (%d)", n, k)
+	}
+	if width := binary.LittleEndian.Uint32(fixed[32:]); int(width) != h.WordWidth() {
+		return Header{}, 0, fmt.Errorf("word width %d, expected %d for n %d", width, h.WordWidth(), h.N)
+	}
+	rankLength := binary.LittleEndian.Uint32(fixed[36:])
+	if uint64(rankLength) > uint64(h.N)/8+1 {
+		return Header{}, 0, fmt.Errorf("starting rank of %d bytes out of range for n %d", rankLength, h.N)
+	}
+	rank := make([]byte, rankLength)
+	if _, err := io.ReadFull(r, rank); err != nil {
+		return Header{}, 0, fmt.Errorf("reading header: %w", err)
+	}
+	for i, j := 0, len(rank)-1; i < j; i, j = i+1, j-1 {
+		rank[i], rank[j] = rank[j], rank[i]
+	}
+	h.Start = new(big.Int).SetBytes(rank)
+	return h, fixedHeaderSize + int64(rankLength), nil
+}
+
+// trailerSize returns the size of the trailer
+func trailerSize(h *Header) int64 {
+	size := int64(h.WordWidth()) + 8
+	if h.Checksum {
+		size += 4
+	}
+	return size
+}
+
+// newChecksum returns the hash of the checksum
+func newChecksum() hash.Hash32 {
+	return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+}
+
+// isZero reports whether all the bytes are 0
+func isZero(b []byte) bool {
+	return len(bytes.TrimLeft(b, "\x00")) == 0
+}
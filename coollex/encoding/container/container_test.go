@@ -0,0 +1,379 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package container
+
+import (
+	"bytes"
+	"io"
+	"iter"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex"
+)
+
+// write64 returns a container of the combinations of ComputerWord64 from `start`
+func write64(t *testing.T, n, k, start uint, checksum bool) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	w, err := NewWriter(&out, Header{N: n, K: k, Algorithm: AlgorithmComputerWord64, Start: big.NewInt(int64(start)), Checksum: checksum})
+	if err != nil {
+		t.Fatal(err)
+	}
+	word, _ := coollex.NewComputerWord64At(n, k, start)
+	if _, err := w.WriteWords64(word.Words()); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+// onlyReader hides the io.Seeker of a reader
+type onlyReader struct{ io.Reader }
+
+func TestRoundTrip64(t *testing.T) {
+	for _, checksum := range []bool{false, true} {
+		data := write64(t, 12, 5, 100, checksum)
+		for _, src := range []io.Reader{bytes.NewReader(data), onlyReader{bytes.NewReader(data)}} {
+			r, err := NewReader(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := r.Header()
+			if h.N != 12 || h.K != 5 || h.Start.Int64() != 100 || h.Checksum != checksum || h.Algorithm != AlgorithmComputerWord64 {
+				t.Fatalf("unexpected header %+v", h)
+			}
+			expect, _ := coollex.NewComputerWord64At(12, 5, 100)
+			actual := slices.Collect(r.Words64())
+			if err := r.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(slices.Collect(expect.Words()), actual) {
+				t.Fatal("the words read differ from the words written")
+			}
+			if r.Rank().Int64() != 792 {
+				t.Fatalf("rank at the end: expected 792, got %v", r.Rank())
+			}
+		}
+	}
+}
+
+func TestRoundTrip63(t *testing.T) {
+	// N=63, the largest for ComputerWord64
+	data := write64(t, 63, 2, 0, true)
+	r, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect, _ := coollex.NewComputerWord64(63, 2)
+	actual := slices.Collect(r.Words64())
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(slices.Collect(expect.Words()), actual) {
+		t.Fatal("the words read differ from the words written")
+	}
+}
+
+func TestRoundTripBig(t *testing.T) {
+	const n, k = 130, 3
+	var out bytes.Buffer
+	w, _ := NewWriter(&out, Header{N: n, K: k, Algorithm: AlgorithmComputerWordBig, Checksum: true})
+	word, _ := coollex.NewComputerWordBig(n, k)
+	if count, err := w.WriteWordsBig(word.Words()); err != nil || count != 357760 {
+		t.Fatalf("written %d, %v", count, err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewReader(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect, _ := coollex.NewComputerWordBig(n, k)
+	next, stop := iter.Pull(expect.Words())
+	defer stop()
+	count := 0
+	for actual := range r.WordsBig() {
+		e, _ := next()
+		if e.Cmp(actual) != 0 {
+			t.Fatalf("word %d: expected %v, got %v", count, e, actual)
+		}
+		count++
+	}
+	if err := r.Err(); err != nil || count != 357760 {
+		t.Fatalf("read %d, %v", count, err)
+	}
+}
+
+func TestSeek(t *testing.T) {
+	data := write64(t, 10, 4, 20, true)
+	all, _ := coollex.NewComputerWord64At(10, 4, 20)
+	expect := slices.Collect(all.Words())
+
+	// seekable: backward and forward
+	r, _ := NewReader(bytes.NewReader(data))
+	if count, err := r.Count(); err != nil || count != 190 {
+		t.Fatalf("count: %d, %v", count, err)
+	}
+	for _, rank := range []int64{150, 20, 209, 100, 210} {
+		if err := r.Seek(big.NewInt(rank)); err != nil {
+			t.Fatal(err)
+		}
+		actual := slices.Collect(r.Words64())
+		if err := r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(expect[rank-20:], actual) {
+			t.Fatalf("rank %d: unexpected words", rank)
+		}
+	}
+	for _, rank := range []int64{19, 211} {
+		if err := r.Seek(big.NewInt(rank)); err == nil {
+			t.Fatalf("error is expected for rank %d out of range", rank)
+		}
+	}
+
+	// not seekable: forward only
+	r, _ = NewReader(onlyReader{bytes.NewReader(data)})
+	if err := r.Seek(big.NewInt(60)); err != nil {
+		t.Fatal(err)
+	}
+	for word := range r.Words64() {
+		if word != expect[40] {
+			t.Fatalf("expected %b, got %b", expect[40], word)
+		}
+		break
+	}
+	if err := r.Seek(big.NewInt(30)); err == nil {
+		t.Fatal("error is expected for seeking backward")
+	}
+	if _, err := r.Count(); err == nil {
+		t.Fatal("error is expected for the count of a container that is not seekable")
+	}
+	if err := r.Seek(big.NewInt(211)); err == nil {
+		t.Fatal("error is expected for rank beyond the last combination")
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	r, _ := NewReader(bytes.NewReader(write64(t, 6, 3, 0, false)))
+	expect, _ := coollex.NewComputerWord64(6, 3)
+	var expectElements, actualElements [][]uint
+	for combination := range expect.Combinations() {
+		expectElements = append(expectElements, slices.Collect(combination))
+	}
+	for combination := range r.Combinations() {
+		actualElements = append(actualElements, slices.Collect(combination))
+	}
+	if !slices.EqualFunc(expectElements, actualElements, slices.Equal) {
+		t.Fatalf("expected %v, got %v", expectElements, actualElements)
+	}
+}
+
+func TestCorruption(t *testing.T) {
+	data := write64(t, 12, 5, 0, true)
+	read := func(data []byte) error {
+		r, err := NewReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		for range r.Words64() {
+		}
+		return r.Err()
+	}
+	if err := read(data); err != nil {
+		t.Fatal(err)
+	}
+	corrupt := slices.Clone(data)
+	corrupt[fixedHeaderSize+8*10] ^= 0x40
+	if err := read(corrupt); err == nil {
+		t.Fatal("error is expected for a checksum mismatch")
+	}
+	if err := read(data[:len(data)-30]); err == nil {
+		t.Fatal("error is expected for a truncated container")
+	}
+	corrupt = slices.Clone(data)
+	corrupt[0] = 'X'
+	if err := read(corrupt); err == nil {
+		t.Fatal("error is expected for invalid magic")
+	}
+	corrupt = slices.Clone(data)
+	corrupt[8] = 2
+	if err := read(corrupt); err == nil {
+		t.Fatal("error is expected for an unsupported version")
+	}
+	corrupt = slices.Clone(data)
+	corrupt[len(corrupt)-12]++ // the count
+	if err := read(corrupt); err == nil {
+		t.Fatal("error is expected for a count mismatch")
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	if _, err := NewWriter(io.Discard, Header{N: 3, K: 4}); err == nil {
+		t.Fatal("error is expected for n<k")
+	}
+	if _, err := NewWriter(io.Discard, Header{N: 3, K: 0}); err == nil {
+		t.Fatal("error is expected for k=0")
+	}
+	if _, err := NewWriter(io.Discard, Header{N: 3, K: 1, Start: big.NewInt(-1)}); err == nil {
+		t.Fatal("error is expected for a negative starting rank")
+	}
+	w, _ := NewWriter(io.Discard, Header{N: 5, K: 2})
+	if err := w.WriteWord64(7); err == nil {
+		t.Fatal("error is expected for a word with other than k bits set")
+	}
+	if err := w.WriteWord64(1 << 5); err == nil {
+		t.Fatal("error is expected for a word with bits out of range")
+	}
+	if err := w.WriteWordBig(big.NewInt(1)); err == nil {
+		t.Fatal("error is expected for a word with other than k bits set")
+	}
+	w.Close()
+	if err := w.WriteWord64(3); err == nil {
+		t.Fatal("error is expected for writing to a closed container")
+	}
+	if err := w.Close(); err == nil {
+		t.Fatal("error is expected for closing a closed container")
+	}
+}
+
+func TestWriterOrder(t *testing.T) {
+	g, _ := coollex.NewComputerWord64(10, 4)
+	all := slices.Collect(g.Words())
+	w, _ := NewWriter(io.Discard, Header{N: 10, K: 4, Start: big.NewInt(20)})
+	if err := w.WriteWord64(all[21]); err == nil {
+		t.Fatal("error is expected for a first word other than the one at rank Start")
+	}
+	for _, word := range all[20:23] {
+		if err := w.WriteWord64(word); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, word := range []int64{all[24], all[22], all[0]} {
+		if err := w.WriteWord64(word); err == nil {
+			t.Fatalf("error is expected for %#x other than the successor %#x", word, all[23])
+		}
+	}
+	if err := w.WriteWordBig(big.NewInt(all[23])); err != nil || w.Count() != 4 {
+		t.Fatalf("count %d, %v", w.Count(), err)
+	}
+
+	// after the last combination
+	w, _ = NewWriter(io.Discard, Header{N: 10, K: 4, Start: big.NewInt(int64(len(all) - 1))})
+	if err := w.WriteWord64(all[len(all)-1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteWord64(all[0]); err == nil {
+		t.Fatal("error is expected for a word after the last combination")
+	}
+	w, _ = NewWriter(io.Discard, Header{N: 10, K: 4, Start: big.NewInt(int64(len(all)))})
+	if err := w.WriteWord64(all[0]); err == nil {
+		t.Fatal("error is expected for a word of an empty container")
+	}
+	if _, err := NewWriter(io.Discard, Header{N: 10, K: 4, Start: big.NewInt(int64(len(all) + 1))}); err == nil {
+		t.Fatal("error is expected for a starting rank beyond C(n,k)")
+	}
+
+	// n>=64
+	big70, _ := coollex.NewComputerWordBigAt(70, 2, big.NewInt(5))
+	next, stop := iter.Pull(big70.Words())
+	defer stop()
+	w, _ = NewWriter(io.Discard, Header{N: 70, K: 2, Start: big.NewInt(5)})
+	first, _ := next()
+	first = new(big.Int).Set(first) // the generator reuses its word
+	if err := w.WriteWordBig(new(big.Int).Neg(first)); err == nil {
+		t.Fatal("error is expected for a negative word")
+	}
+	if err := w.WriteWordBig(first); err != nil {
+		t.Fatal(err)
+	}
+	second, _ := next()
+	if err := w.WriteWordBig(first); err == nil {
+		t.Fatal("error is expected for a repeated word")
+	}
+	if err := w.WriteWord64(second.Int64()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCountWhileReading(t *testing.T) {
+	data := write64(t, 10, 4, 0, true)
+	all, _ := coollex.NewComputerWord64(10, 4)
+	expect := slices.Collect(all.Words())
+	r, _ := NewReader(bytes.NewReader(data))
+	var actual []int64
+	for word := range r.Words64() {
+		actual = append(actual, word)
+		if len(actual) == 5 {
+			break
+		}
+	}
+	actual = actual[:4] // the word for which yield returned false is read again
+	if count, err := r.Count(); err != nil || count != uint64(len(expect)) {
+		t.Fatalf("count: expected %d, got %d, %v", len(expect), count, err)
+	}
+	actual = append(actual, slices.Collect(r.Words64())...)
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(expect, actual) {
+		t.Fatalf("expected %v, got %v", expect, actual)
+	}
+	// once done
+	if count, err := r.Count(); err != nil || count != uint64(len(expect)) {
+		t.Fatalf("count: expected %d, got %d, %v", len(expect), count, err)
+	}
+	if len(slices.Collect(r.Words64())) != 0 || r.Err() != nil {
+		t.Fatalf("expected no words and no error once done, got %v", r.Err())
+	}
+}
+
+func TestResume(t *testing.T) {
+	data := write64(t, 10, 4, 0, true)
+	all, _ := coollex.NewComputerWord64(10, 4)
+	expect := slices.Collect(all.Words())
+	for _, src := range []io.Reader{bytes.NewReader(data), onlyReader{bytes.NewReader(data)}} {
+		r, _ := NewReader(src)
+		var actual []int64
+		for {
+			i := 0
+			for word := range r.Words64() {
+				actual = append(actual, word)
+				if i++; i == 7 {
+					break
+				}
+			}
+			if i < 7 {
+				break
+			}
+			// resumes at the word for which yield returned false
+			actual = actual[:len(actual)-1]
+			if rank := r.Rank().Int64(); rank != int64(len(actual)) {
+				t.Fatalf("rank: expected %d, got %d", len(actual), rank)
+			}
+			if err := r.Seek(r.Rank()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(expect, actual) {
+			t.Fatalf("expected %v, got %v", expect, actual)
+		}
+	}
+}
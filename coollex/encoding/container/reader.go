@@ -0,0 +1,322 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"iter"
+	"math/big"
+	"math/bits"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex"
+)
+
+// Reader reads a container. The iterators stop at the end of the body, or upon an error, reported by Err. Like
+// the generators, when an iterator is called again, it resumes at the combination for which `yield` returned
+// false.
+type Reader struct {
+	src        io.Reader
+	seeker     io.Seeker // src, if seekable
+	in         *bufio.Reader
+	header     Header
+	headerSize int64
+	word       []byte
+	index      uint64 // of the next word in the body
+	count      uint64 // from the trailer, if known
+	countKnown bool
+	checksum   hash.Hash32 // nil if not verified, for example upon seeking
+	pending    bool        // the word for which yield returned false, to yield again
+	done       bool
+	err        error
+}
+
+// NewReader reads the header from `r` and returns a Reader of the body. If `r` is an io.Seeker, positioned at
+// the start of the container, the Reader can seek backward, and in constant time.
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{src: r}
+	if seeker, ok := r.(io.Seeker); ok {
+		reader.seeker = seeker
+	}
+	reader.in = bufio.NewReaderSize(r, 64<<10)
+	checksum := newChecksum()
+	h, size, err := readHeader(io.TeeReader(reader.in, checksum))
+	if err != nil {
+		return nil, err
+	}
+	reader.header, reader.headerSize = h, size
+	reader.word = make([]byte, h.WordWidth())
+	if h.Checksum {
+		reader.checksum = checksum
+	}
+	return reader, nil
+}
+
+// Header returns the header of the container.
+func (r *Reader) Header() Header {
+	h := r.header
+	h.Start = new(big.Int).Set(r.header.Start)
+	return h
+}
+
+// Rank returns the rank of the next combination.
+func (r *Reader) Rank() *big.Int {
+	index := r.index
+	if r.pending {
+		index--
+	}
+	rank := new(big.Int).SetUint64(index)
+	return rank.Add(rank, r.header.Start)
+}
+
+// Err returns the first error encountered while reading, if any.
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// fail records the first error, and ends the reading
+func (r *Reader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+	r.done = true
+}
+
+// read reads the next word of the body into `r.word`, and reports whether there was one; at the end of the
+// body, it verifies the trailer
+func (r *Reader) read() bool {
+	if r.pending {
+		r.pending = false
+		return true
+	}
+	if r.done {
+		return false
+	}
+	if _, err := io.ReadFull(r.in, r.word); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			err = errors.New("incomplete container: no trailer")
+		}
+		r.fail(err)
+		return false
+	}
+	if r.checksum != nil {
+		r.checksum.Write(r.word)
+	}
+	if !isZero(r.word) {
+		r.index++
+		return true
+	}
+
+	// the trailer
+	r.done = true
+	var count [8]byte
+	if _, err := io.ReadFull(r.in, count[:]); err != nil {
+		r.fail(fmt.Errorf("reading trailer: %w", err))
+		return false
+	}
+	if c := binary.LittleEndian.Uint64(count[:]); c != r.index {
+		r.fail(fmt.Errorf("count %d in the trailer, found %d words", c, r.index))
+		return false
+	}
+	if r.header.Checksum {
+		var sum [4]byte
+		if _, err := io.ReadFull(r.in, sum[:]); err != nil {
+			r.fail(fmt.Errorf("reading trailer: %w", err))
+			return false
+		}
+		if r.checksum != nil {
+			r.checksum.Write(count[:])
+			if expect := binary.LittleEndian.Uint32(sum[:]); expect != r.checksum.Sum32() {
+				r.fail(fmt.Errorf("checksum mismatch: expected %#08x, got %#08x", expect, r.checksum.Sum32()))
+			}
+		}
+	}
+	return false
+}
+
+// Count returns the number of combinations in the container, read from the trailer. It can be called at any
+// time while reading, which then resumes where it was.
+//
+// It is an error to call Count unless the source is an io.Seeker.
+func (r *Reader) Count() (uint64, error) {
+	if r.countKnown {
+		return r.count, nil
+	}
+	if r.seeker == nil {
+		return 0, errors.New("count is unknown: the container is not seekable")
+	}
+	size := trailerSize(&r.header)
+	end, err := r.seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	offset := end - size
+	if offset < r.headerSize {
+		return 0, errors.New("incomplete container: no trailer")
+	}
+	if _, err := r.seeker.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	trailer := make([]byte, size)
+	if _, err := io.ReadFull(r.src, trailer); err != nil {
+		return 0, err
+	}
+	width := r.header.WordWidth()
+	count := binary.LittleEndian.Uint64(trailer[width:])
+	if !isZero(trailer[:width]) || (offset-r.headerSize)/int64(width) != int64(count) {
+		return 0, errors.New("incomplete container: invalid trailer")
+	}
+	r.count, r.countKnown = count, true
+	// resume reading where it was, keeping the word to yield again, if any
+	pending, done, readErr := r.pending, r.done, r.err
+	if err := r.seekIndex(r.index); err != nil {
+		return 0, err
+	}
+	r.pending, r.done, r.err = pending, done, readErr
+	return count, nil
+}
+
+// seekIndex positions the seekable source at the word of the index
+func (r *Reader) seekIndex(index uint64) error {
+	if _, err := r.seeker.Seek(r.headerSize+int64(index)*int64(r.header.WordWidth()), io.SeekStart); err != nil {
+		return err
+	}
+	r.in.Reset(r.src)
+	r.index, r.pending, r.done, r.err = index, false, false, nil
+	return nil
+}
+
+// Seek positions the reader at the combination at `rank`, so that it is read next; seeking to the rank that
+// follows the last combination positions the reader at the end of the body. Unless the source is an
+// io.Seeker, the reader can only seek forward, reading the preceding words. The checksum is not verified once
+// the reader has sought in the io.Seeker.
+//
+// It is an error to pass a rank out of range.
+func (r *Reader) Seek(rank *big.Int) error {
+	offset := new(big.Int).Sub(rank, r.header.Start)
+	if offset.Sign() < 0 || !offset.IsUint64() || offset.Uint64() > 1<<62 {
+		return fmt.Errorf("rank %v out of range", rank)
+	}
+	index := offset.Uint64()
+	if r.seeker != nil {
+		count, err := r.Count()
+		if err != nil {
+			return err
+		}
+		if index > count {
+			return fmt.Errorf("rank %v out of range [%v, %v]", rank, r.header.Start, new(big.Int).Add(r.header.Start, new(big.Int).SetUint64(count)))
+		}
+		r.checksum = nil
+		return r.seekIndex(index)
+	}
+	next := r.index // of the next combination
+	if r.pending {
+		next--
+	}
+	if index < next {
+		return fmt.Errorf("rank %v precedes the rank of the next combination, %v, and the container is not seekable", rank, r.Rank())
+	}
+	if index == next {
+		return nil
+	}
+	r.pending = false
+	for r.index < index {
+		if !r.read() {
+			if r.err != nil {
+				return r.err
+			}
+			return fmt.Errorf("rank %v out of range, beyond the last combination", rank)
+		}
+	}
+	return nil
+}
+
+// Words returns an iterator over the remaining words, each of Header().WordWidth() bytes in little-endian byte
+// order.
+//
+// Note: the slice is reused between iterations and should only be used for reading.
+func (r *Reader) Words() iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		for r.read() {
+			if !yield(r.word) {
+				r.pending = true
+				return
+			}
+		}
+	}
+}
+
+// Words64 returns an iterator over the remaining combinations represented as in `ComputerWord64.Words()`.
+//
+// It is an error, reported by Err, to call Words64 for n >= 64.
+func (r *Reader) Words64() iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		if r.header.N >= 64 {
+			r.fail(fmt.Errorf("n (%d) greater than 63, consider using WordsBig", r.header.N))
+			return
+		}
+		for r.read() {
+			if !yield(int64(binary.LittleEndian.Uint64(r.word))) {
+				r.pending = true
+				return
+			}
+		}
+	}
+}
+
+// WordsBig returns an iterator over the remaining combinations represented as in `ComputerWordBig.Words()`.
+//
+// Note: the value is reused between iterations and should only be used for reading.
+func (r *Reader) WordsBig() iter.Seq[*big.Int] {
+	return func(yield func(*big.Int) bool) {
+		word := new(big.Int)
+		limbs := make([]big.Word, (len(r.word)*8+bits.UintSize-1)/bits.UintSize)
+		for r.read() {
+			clear(limbs)
+			for i, b := range r.word {
+				limbs[i*8/bits.UintSize] |= big.Word(b) << (i * 8 % bits.UintSize)
+			}
+			if !yield(word.SetBits(limbs)) {
+				r.pending = true
+				return
+			}
+		}
+	}
+}
+
+// Combinations returns an iterator over the remaining combinations.
+func (r *Reader) Combinations() coollex.Combinations {
+	return func(yield func(coollex.Elements) bool) {
+		for r.read() {
+			if !yield(r.elements) {
+				r.pending = true
+				return
+			}
+		}
+	}
+}
+
+// elements yields the elements of the current word
+func (r *Reader) elements(yield func(uint) bool) {
+	for i, b := range r.word {
+		for ; b != 0; b &= b - 1 {
+			if !yield(uint(i*8 + bits.TrailingZeros8(b))) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"iter"
+	"math/big"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex"
+	"github.com/dastoikov/cool-lex-go/v2/coollex/encoding"
+)
+
+// Writer writes a container. The words are buffered; call Close once done, to write the trailer.
+//
+// The body holds consecutive combinations in Cool-lex order, from the one at rank Start, as Reader.Seek
+// relies on; the Writer rejects any other word.
+type Writer struct {
+	dst      io.Writer
+	out      io.Writer // dst, and the checksum if any
+	checksum hash.Hash32
+	words    *encoding.Writer
+	header   Header
+	count    uint64
+	closed   bool
+
+	// the word expected next, the successor of the one written last: for N<64, next64; next otherwise
+	next64 int64
+	next   *big.Int
+	ended  bool // whether the last combination has been written, or Start is C(N,K)
+}
+
+// NewWriter writes the header to `w` and returns a Writer of the body.
+//
+// It is an error to pass a header such that N < K, K = 0, or Start is not in the range [0, C(N,K)].
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	b, err := header.marshal()
+	if err != nil {
+		return nil, err
+	}
+	writer := &Writer{dst: w, out: w, header: header}
+	count := new(big.Int).Binomial(int64(header.N), int64(header.K))
+	switch start := header.start(); start.Cmp(count) {
+	case 1:
+		return nil, fmt.Errorf("starting rank %v out of range [0, %v]", start, count)
+	case 0:
+		writer.ended = true
+	default:
+		first, _ := coollex.UnrankBig(start, header.N, header.K) // no error for a rank in range
+		writer.next = new(big.Int)
+		for _, element := range first {
+			writer.next.SetBit(writer.next, int(element), 1)
+		}
+		if header.N < 64 {
+			writer.next64, writer.next = writer.next.Int64(), nil
+		}
+	}
+	if header.Checksum {
+		writer.checksum = newChecksum()
+		writer.out = io.MultiWriter(w, writer.checksum)
+	}
+	if _, err := writer.out.Write(b); err != nil {
+		return nil, err
+	}
+	writer.words = encoding.NewWriter(writer.out, encoding.Binary, header.N)
+	return writer, nil
+}
+
+// checkWritable reports an error if the writer has been closed
+func (w *Writer) checkWritable() error {
+	if w.closed {
+		return errors.New("write to a closed container")
+	}
+	return nil
+}
+
+// unexpected returns the error for a word other than the one expected next
+func (w *Writer) unexpected(word *big.Int) error {
+	if w.ended {
+		return fmt.Errorf("word %#x after the last combination", word)
+	}
+	next := w.next
+	if next == nil {
+		next = big.NewInt(w.next64)
+	}
+	rank := new(big.Int).Add(w.header.start(), new(big.Int).SetUint64(w.count))
+	return fmt.Errorf("word %#x, expected %#x, the combination at rank %v", word, next, rank)
+}
+
+// advance expects next the successor of the word expected so far
+func (w *Writer) advance() {
+	var more bool
+	if w.next == nil {
+		w.next64, more = coollex.NextWord64(w.next64, w.header.N)
+	} else {
+		more = coollex.NextWordBig(w.next, w.next, w.header.N)
+	}
+	w.ended = !more
+	w.count++
+}
+
+// WriteWord64 writes a combination represented as in `ComputerWord64.Words()`.
+//
+// It is an error to pass a word other than the combination at rank Start, first, and the successor in Cool-lex
+// order of the word written last, afterwards.
+func (w *Writer) WriteWord64(word int64) error {
+	if err := w.checkWritable(); err != nil {
+		return err
+	}
+	expected := word == w.next64
+	if w.next != nil {
+		expected = w.next.IsUint64() && w.next.Uint64() == uint64(word)
+	}
+	if w.ended || !expected {
+		return w.unexpected(big.NewInt(word))
+	}
+	if err := w.words.WriteWord64(word); err != nil {
+		return err
+	}
+	w.advance()
+	return nil
+}
+
+// WriteWords64 writes the combinations represented as in `ComputerWord64.Words()`, and returns the number of
+// combinations written.
+func (w *Writer) WriteWords64(words iter.Seq[int64]) (uint64, error) {
+	count := uint64(0)
+	for word := range words {
+		if err := w.WriteWord64(word); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// WriteWordBig writes a combination represented as in `ComputerWordBig.Words()`.
+//
+// It is an error to pass a word other than the one expected, see WriteWord64.
+func (w *Writer) WriteWordBig(word *big.Int) error {
+	if err := w.checkWritable(); err != nil {
+		return err
+	}
+	if word.Sign() < 0 {
+		return fmt.Errorf("negative word %#x", word)
+	}
+	expected := word.IsInt64() && word.Int64() == w.next64
+	if w.next != nil {
+		expected = word.Cmp(w.next) == 0
+	}
+	if w.ended || !expected {
+		return w.unexpected(word)
+	}
+	if err := w.words.WriteWordBig(word); err != nil {
+		return err
+	}
+	w.advance()
+	return nil
+}
+
+// WriteWordsBig writes the combinations represented as in `ComputerWordBig.Words()`, and returns the number
+// of combinations written.
+func (w *Writer) WriteWordsBig(words iter.Seq[*big.Int]) (uint64, error) {
+	count := uint64(0)
+	for word := range words {
+		if err := w.WriteWordBig(word); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Count returns the number of combinations written.
+func (w *Writer) Count() uint64 {
+	return w.count
+}
+
+// Close flushes the body and writes the trailer. It does not close the underlying io.Writer.
+func (w *Writer) Close() error {
+	if err := w.checkWritable(); err != nil {
+		return err
+	}
+	w.closed = true
+	if err := w.words.Flush(); err != nil {
+		return err
+	}
+	trailer := make([]byte, w.header.WordWidth(), trailerSize(&w.header))
+	trailer = binary.LittleEndian.AppendUint64(trailer, w.count)
+	if _, err := w.out.Write(trailer); err != nil {
+		return err
+	}
+	if w.checksum != nil {
+		if _, err := w.dst.Write(binary.LittleEndian.AppendUint32(nil, w.checksum.Sum32())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
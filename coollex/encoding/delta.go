@@ -0,0 +1,384 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoding
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math/big"
+	"math/bits"
+
+	"github.com/dastoikov/cool-lex-go/v2/internal/bitword"
+)
+
+// deltaMagic identifies the delta format
+const deltaMagic = "CLXD"
+
+const deltaVersion = 1
+
+// limbs is a word of `big.Word` limbs, the least-significant first
+type limbs []big.Word
+
+// highestDifference returns 1 + the position of the highest bit in which the words differ, or 0 if equal
+func (l limbs) highestDifference(other limbs) uint {
+	for i := len(l) - 1; i >= 0; i-- {
+		if d := l[i] ^ other[i]; d != 0 {
+			return uint(i*bits.UintSize + bits.Len(uint(d)))
+		}
+	}
+	return 0
+}
+
+// limbs64 returns the limbs of `w`
+func limbs64(w uint64) limbs {
+	if bits.UintSize == 32 {
+		return limbs{big.Word(w), big.Word(w >> 32)}
+	}
+	return limbs{big.Word(w)}
+}
+
+// rotatePrefix64 rotates the bits [0, m) of `w` by one position: the bit m-1 moves to 0. Precondition: `m>=1`.
+func rotatePrefix64(w uint64, m uint) uint64 {
+	mask := ^uint64(0) >> (64 - m)
+	return w&^mask | (w<<1)&mask | w>>(m-1)&1
+}
+
+// DeltaWriter writes combinations, of k out of n elements, in Cool-lex order, in the delta format. The
+// combinations are buffered; call Flush once done.
+//
+// The delta format stores a stream of combinations, in Cool-lex order, in about one byte per combination for
+// n<128. In Cool-lex order, each combination is obtained from its predecessor by rotating a prefix, of length
+// m>=2, by one position: the element at position m-1 moves to position 0, and the elements at positions
+// [0, m-1) move up by one position. Hence, it suffices to store the first combination, and m for every step.
+//
+// The format, with integers in little-endian byte order and varints as in encoding/binary:
+//
+//	magic   4 bytes, "CLXD"
+//	version uint8, 1
+//	n       uvarint
+//	k       uvarint
+//	first   the first combination, if any, as in the Binary format: a word of WordWidth(n) bytes
+//	steps   uvarint m for every following combination
+//
+// The stream ends at the end of the input.
+type DeltaWriter struct {
+	out     *bufio.Writer
+	n, k    uint
+	started bool
+	prev64  uint64
+	prev    limbs // for n>=64
+	scratch limbs
+	word    *big.Int // for n>=64, a 64-bit word to write
+	buf     []byte
+}
+
+// NewDeltaWriter writes the header of the delta format to `w` and returns a DeltaWriter.
+//
+// It is an error to pass arguments such that n < k or k = 0.
+func NewDeltaWriter(w io.Writer, n, k uint) (*DeltaWriter, error) {
+	if n < k {
+		return nil, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	if k == 0 {
+		return nil, fmt.Errorf("no combinations for k=0")
+	}
+	writer := &DeltaWriter{out: bufio.NewWriterSize(w, 64<<10), n: n, k: k}
+	if n >= 64 {
+		size := (n + bits.UintSize - 1) / bits.UintSize
+		writer.prev, writer.scratch, writer.word = make(limbs, size), make(limbs, size), new(big.Int)
+	}
+	header := append([]byte(deltaMagic), deltaVersion)
+	header = binary.AppendUvarint(header, uint64(n))
+	header = binary.AppendUvarint(header, uint64(k))
+	_, err := writer.out.Write(header)
+	return writer, err
+}
+
+// writeStep writes the rotation length, or an error unless m>=2
+func (w *DeltaWriter) writeStep(m uint) error {
+	if m < 2 {
+		return errors.New("not a successor in Cool-lex order: no rotation")
+	}
+	w.buf = binary.AppendUvarint(w.buf[:0], uint64(m))
+	_, err := w.out.Write(w.buf)
+	return err
+}
+
+// writeFirst writes the first combination, after checking that it has k elements
+func (w *DeltaWriter) writeFirst(word limbs) error {
+	ones := uint(0)
+	for _, v := range word {
+		ones += uint(bits.OnesCount(uint(v)))
+	}
+	if ones != w.k {
+		return fmt.Errorf("word with %d bits set, expected k=%d", ones, w.k)
+	}
+	w.started = true
+	w.buf = w.buf[:0]
+	for i := range WordWidth(w.n) {
+		w.buf = append(w.buf, byte(word[i*8/bits.UintSize]>>(i*8%bits.UintSize)))
+	}
+	_, err := w.out.Write(w.buf)
+	return err
+}
+
+// WriteWord64 writes a combination represented as in `ComputerWord64.Words()`.
+//
+// It is an error to pass a word that does not follow the previous one in Cool-lex order, or, for the first
+// word, without exactly k bits set in the range [0, n).
+func (w *DeltaWriter) WriteWord64(word int64) error {
+	if w.n >= 64 {
+		return w.WriteWordBig(w.word.SetInt64(word))
+	}
+	v := uint64(word)
+	if v>>w.n != 0 {
+		return fmt.Errorf("word %#x out of range for n %d", word, w.n)
+	}
+	if !w.started {
+		w.prev64 = v
+		return w.writeFirst(limbs64(v))
+	}
+	m := uint(bits.Len64(w.prev64 ^ v))
+	if m < 2 || rotatePrefix64(w.prev64, m) != v {
+		return fmt.Errorf("word %#x does not follow %#x in Cool-lex order", v, w.prev64)
+	}
+	w.prev64 = v
+	return w.writeStep(m)
+}
+
+// WriteWords64 writes the combinations represented as in `ComputerWord64.Words()`, and returns the number of
+// combinations written.
+func (w *DeltaWriter) WriteWords64(words iter.Seq[int64]) (uint64, error) {
+	count := uint64(0)
+	for word := range words {
+		if err := w.WriteWord64(word); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// WriteWordBig writes a combination represented as in `ComputerWordBig.Words()`.
+//
+// It is an error to pass a word that does not follow the previous one in Cool-lex order, or, for the first
+// word, without exactly k bits set in the range [0, n).
+func (w *DeltaWriter) WriteWordBig(word *big.Int) error {
+	if word.Sign() < 0 || uint(word.BitLen()) > w.n {
+		return fmt.Errorf("word %#x out of range for n %d", word, w.n)
+	}
+	if w.n < 64 {
+		return w.WriteWord64(int64(word.Uint64()))
+	}
+	current := w.scratch
+	clear(current)
+	copy(current, word.Bits())
+	if !w.started {
+		copy(w.prev, current)
+		return w.writeFirst(current)
+	}
+	m := w.prev.highestDifference(current)
+	if m >= 2 {
+		bitword.RotatePrefix(w.prev, m)
+	}
+	if m < 2 || w.prev.highestDifference(current) != 0 {
+		copy(w.prev, current) // not restored; the stream is invalid anyway
+		return fmt.Errorf("word %#x does not follow the previous one in Cool-lex order", word)
+	}
+	return w.writeStep(m)
+}
+
+// WriteWordsBig writes the combinations represented as in `ComputerWordBig.Words()`, and returns the number
+// of combinations written.
+func (w *DeltaWriter) WriteWordsBig(words iter.Seq[*big.Int]) (uint64, error) {
+	count := uint64(0)
+	for word := range words {
+		if err := w.WriteWordBig(word); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *DeltaWriter) Flush() error {
+	return w.out.Flush()
+}
+
+// DeltaReader reads combinations in the delta format, see DeltaWriter. The iterators stop at the end of the
+// input, or upon an error, reported by Err. Like the generators, when an iterator is called again, it resumes at
+// the combination for which `yield` returned false.
+type DeltaReader struct {
+	in      *bufio.Reader
+	n, k    uint
+	started bool
+	pending bool   // the combination for which yield returned false, to yield again
+	word64  uint64 // the combination, for n<64
+	word    limbs  // the combination, for n>=64
+	err     error
+}
+
+// NewDeltaReader reads the header of the delta format from `r` and returns a DeltaReader.
+func NewDeltaReader(r io.Reader) (*DeltaReader, error) {
+	in := bufio.NewReaderSize(r, 64<<10)
+	var magic [len(deltaMagic) + 1]byte
+	if _, err := io.ReadFull(in, magic[:]); err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	if string(magic[:len(deltaMagic)]) != deltaMagic {
+		return nil, errors.New("not a delta stream: invalid magic")
+	}
+	if version := magic[len(deltaMagic)]; version != deltaVersion {
+		return nil, fmt.Errorf("unsupported version %d", version)
+	}
+	n, err := binary.ReadUvarint(in)
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	k, err := binary.ReadUvarint(in)
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	if uint64(uint(n)) != n || k == 0 || k > n {
+		return nil, fmt.Errorf("invalid n (%d) and k (%d)", n, k)
+	}
+	reader := &DeltaReader{in: in, n: uint(n), k: uint(k)}
+	if n >= 64 {
+		reader.word = make(limbs, (n+bits.UintSize-1)/bits.UintSize)
+	}
+	return reader, nil
+}
+
+// N returns the number of elements to combine.
+func (r *DeltaReader) N() uint {
+	return r.n
+}
+
+// K returns the number of elements in each combination.
+func (r *DeltaReader) K() uint {
+	return r.k
+}
+
+// Err returns the first error encountered while reading, if any.
+func (r *DeltaReader) Err() error {
+	return r.err
+}
+
+// readFirst reads the first combination
+func (r *DeltaReader) readFirst() bool {
+	first := make([]byte, WordWidth(r.n))
+	if _, err := io.ReadFull(r.in, first); err != nil {
+		if !errors.Is(err, io.EOF) {
+			r.err = fmt.Errorf("reading the first combination: %w", err)
+		}
+		return false
+	}
+	ones := uint(0)
+	for i, b := range first {
+		if b == 0 {
+			continue
+		}
+		if uint(i*8+bits.Len8(b)) > r.n {
+			r.err = fmt.Errorf("the first combination out of range for n %d", r.n)
+			return false
+		}
+		if r.word != nil {
+			r.word[i*8/bits.UintSize] |= big.Word(b) << (i * 8 % bits.UintSize)
+		} else {
+			r.word64 |= uint64(b) << (i * 8)
+		}
+		ones += uint(bits.OnesCount8(b))
+	}
+	if ones != r.k {
+		r.err = fmt.Errorf("the first combination with %d elements, expected k=%d", ones, r.k)
+		return false
+	}
+	return true
+}
+
+// read reads the next combination, and reports whether there was one
+func (r *DeltaReader) read() bool {
+	if r.pending {
+		r.pending = false
+		return true
+	}
+	if r.err != nil {
+		return false
+	}
+	if !r.started {
+		r.started = true
+		return r.readFirst()
+	}
+	m, err := binary.ReadUvarint(r.in)
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			r.err = fmt.Errorf("reading a step: %w", err)
+		}
+		return false
+	}
+	if m < 2 || m > uint64(r.n) {
+		r.err = fmt.Errorf("rotation of length %d out of range [2, %d]", m, r.n)
+		return false
+	}
+	if r.word != nil {
+		bitword.RotatePrefix(r.word, uint(m))
+	} else {
+		r.word64 = rotatePrefix64(r.word64, uint(m))
+	}
+	return true
+}
+
+// Words64 returns an iterator over the combinations represented as in `ComputerWord64.Words()`.
+//
+// It is an error, reported by Err, to call Words64 for n >= 64.
+func (r *DeltaReader) Words64() iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		if r.n >= 64 {
+			r.err = fmt.Errorf("n (%d) greater than 63, consider using WordsBig", r.n)
+			return
+		}
+		for r.read() {
+			if !yield(int64(r.word64)) {
+				r.pending = true
+				return
+			}
+		}
+	}
+}
+
+// WordsBig returns an iterator over the combinations represented as in `ComputerWordBig.Words()`.
+//
+// Note: the value is reused between iterations and should only be used for reading.
+func (r *DeltaReader) WordsBig() iter.Seq[*big.Int] {
+	return func(yield func(*big.Int) bool) {
+		word := new(big.Int)
+		value := make(limbs, len(r.word))
+		for r.read() {
+			if r.word != nil {
+				copy(value, r.word)
+				word.SetBits(value)
+			} else {
+				word.SetUint64(r.word64)
+			}
+			if !yield(word) {
+				r.pending = true
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package encoding
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex"
+)
+
+func TestDelta64(t *testing.T) {
+	for _, tc := range []struct{ n, k uint }{{1, 1}, {4, 2}, {20, 7}, {63, 2}, {63, 62}} {
+		generator, _ := coollex.NewComputerWord64(tc.n, tc.k)
+		expect := slices.Collect(generator.Words())
+
+		var out bytes.Buffer
+		w, err := NewDeltaWriter(&out, tc.n, tc.k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count, err := w.WriteWords64(slices.Values(expect)); err != nil || count != uint64(len(expect)) {
+			t.Fatalf("n=%d, k=%d: wrote %d combinations, %v", tc.n, tc.k, count, err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		// the header and the first word, and a byte per step
+		if size := out.Len(); size != 4+1+1+1+8+len(expect)-1 {
+			t.Fatalf("n=%d, k=%d: %d bytes for %d combinations", tc.n, tc.k, size, len(expect))
+		}
+
+		r, err := NewDeltaReader(bytes.NewReader(out.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.N() != tc.n || r.K() != tc.k {
+			t.Fatalf("expected n=%d, k=%d, got n=%d, k=%d", tc.n, tc.k, r.N(), r.K())
+		}
+		if actual := slices.Collect(r.Words64()); r.Err() != nil || !slices.Equal(actual, expect) {
+			t.Fatalf("n=%d, k=%d: expected %x, got %x, %v", tc.n, tc.k, expect, actual, r.Err())
+		}
+	}
+}
+
+func TestDeltaBig(t *testing.T) {
+	for _, tc := range []struct{ n, k uint }{{4, 2}, {64, 2}, {130, 3}, {70, 68}} {
+		generator, _ := coollex.NewComputerWordBig(tc.n, tc.k)
+		var expect []*big.Int
+		for word := range generator.Words() {
+			expect = append(expect, new(big.Int).Set(word))
+		}
+
+		var out bytes.Buffer
+		w, err := NewDeltaWriter(&out, tc.n, tc.k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		generator, _ = coollex.NewComputerWordBig(tc.n, tc.k)
+		if count, err := w.WriteWordsBig(generator.Words()); err != nil || count != uint64(len(expect)) {
+			t.Fatalf("n=%d, k=%d: wrote %d combinations, %v", tc.n, tc.k, count, err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := NewDeltaReader(&out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		i := 0
+		for word := range r.WordsBig() {
+			if i >= len(expect) || word.Cmp(expect[i]) != 0 {
+				t.Fatalf("n=%d, k=%d: unexpected word %x at %d", tc.n, tc.k, word, i)
+			}
+			i++
+		}
+		if r.Err() != nil || i != len(expect) {
+			t.Fatalf("n=%d, k=%d: read %d of %d combinations, %v", tc.n, tc.k, i, len(expect), r.Err())
+		}
+	}
+}
+
+func TestDeltaResume(t *testing.T) {
+	var out bytes.Buffer
+	w, _ := NewDeltaWriter(&out, 6, 3)
+	generator, _ := coollex.NewComputerWord64(6, 3)
+	w.WriteWords64(generator.Words())
+	w.Flush()
+
+	generator, _ = coollex.NewComputerWord64(6, 3)
+	expect := slices.Collect(generator.Words())
+	r, _ := NewDeltaReader(&out)
+	var actual []int64
+	for word := range r.Words64() {
+		actual = append(actual, word)
+		if len(actual) == 5 {
+			break
+		}
+	}
+	// resumes at the combination for which yield returned false
+	for word := range r.Words64() {
+		actual = append(actual, word)
+	}
+	if !slices.Equal(actual[:5], expect[:5]) || !slices.Equal(actual[5:], expect[4:]) {
+		t.Fatalf("expected %x, then from %x, got %x", expect[:5], expect[4], actual)
+	}
+}
+
+func TestDeltaErrors(t *testing.T) {
+	if _, err := NewDeltaWriter(io.Discard, 3, 4); err == nil {
+		t.Fatal("error is expected for n<k")
+	}
+	if _, err := NewDeltaWriter(io.Discard, 3, 0); err == nil {
+		t.Fatal("error is expected for k=0")
+	}
+	w, _ := NewDeltaWriter(io.Discard, 5, 2)
+	if err := w.WriteWord64(0b111); err == nil {
+		t.Fatal("error is expected for a word with 3 elements")
+	}
+	if err := w.WriteWord64(0b100001); err == nil {
+		t.Fatal("error is expected for an out-of-range word")
+	}
+	if err := w.WriteWord64(0b00011); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteWord64(0b00101); err == nil {
+		t.Fatal("error is expected for a word that does not follow in Cool-lex order")
+	}
+	if err := w.WriteWord64(0b00011); err == nil {
+		t.Fatal("error is expected for a repeated word")
+	}
+	wide, _ := NewDeltaWriter(io.Discard, 70, 2)
+	first := new(big.Int).SetUint64(0b11)
+	if err := wide.WriteWordBig(first); err != nil {
+		t.Fatal(err)
+	}
+	if err := wide.WriteWordBig(new(big.Int).SetBit(first, 69, 1)); err == nil {
+		t.Fatal("error is expected for a word that does not follow in Cool-lex order")
+	}
+
+	var valid bytes.Buffer
+	w, _ = NewDeltaWriter(&valid, 5, 2)
+	generator, _ := coollex.NewComputerWord64(5, 2)
+	w.WriteWords64(generator.Words())
+	w.Flush()
+	stream := valid.Bytes()
+	for name, input := range map[string][]byte{
+		"magic":          append([]byte("CLXE"), stream[4:]...),
+		"version":        append(append([]byte("CLXD"), 2), stream[5:]...),
+		"header":         stream[:5],
+		"first":          stream[:10],
+		"k":              append(append([]byte("CLXD\x01"), 5, 0), stream[7:]...),
+		"step":           append(slices.Clone(stream), 6),
+		"truncated step": append(slices.Clone(stream), 0x80),
+		"first elements": append(append([]byte("CLXD\x01"), 5, 2, 7), stream[8:]...),
+	} {
+		r, err := NewDeltaReader(bytes.NewReader(input))
+		if err != nil {
+			continue
+		}
+		for range r.Words64() {
+		}
+		if r.Err() == nil {
+			t.Fatalf("%s: error is expected", name)
+		}
+	}
+}
+
+func TestDeltaNoAllocations(t *testing.T) {
+	// the first C(63,3) combinations of k=3 out of n=70 elements are those of n=63
+	word64, _ := coollex.NewComputerWord64(63, 3)
+	words := slices.Collect(word64.Words())
+	for _, n := range []uint{63, 70} {
+		w, _ := NewDeltaWriter(io.Discard, n, 3)
+		i := 0
+		if allocs := testing.AllocsPerRun(100, func() {
+			if err := w.WriteWord64(words[i]); err != nil {
+				t.Fatal(err)
+			}
+			i++
+		}); allocs != 0 {
+			t.Fatalf("n=%d: %v allocations per combination", n, allocs)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package encoding writes streams of combinations, of k out of n elements, to an io.Writer.
+
+A Writer consumes combinations either as `coollex.Elements`, for example from `Combinations()`, or as words,
+for example from `ComputerWord64.Words()` and `ComputerWordBig.Words()`. Output is buffered and, once the
+buffers have grown to the size of a combination, written without allocations.
+
+The formats are:
+  - Indices: the elements, in ascending order, separated by spaces, one combination per line
+  - CSV: the elements, in ascending order, separated by commas, one combination per line
+  - Bits: n characters, '1' for a selected element and '0' otherwise, the element 0 first, one combination
+    per line
+  - Hex: the combination as a word, whose bit i is set for a selected element i, in hexadecimal with
+    ceil(n/4) digits, one combination per line
+  - NDJSON: a JSON object per line, {"rank":R,"elements":[...]}, where R is the position of the combination in
+    the stream, offset by the starting rank, see Writer.SetRank
+  - Binary: the combination as a word, whose bit i is set for a selected element i, in little-endian byte
+    order, in WordWidth(n) bytes
+
+DeltaWriter and DeltaReader implement a compact binary format for streams in Cool-lex order, see DeltaWriter.
+*/
+package encoding
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"iter"
+	"math/big"
+	"math/bits"
+	"slices"
+	"strconv"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex"
+)
+
+// Format is an output format.
+type Format int
+
+// The output formats, see the package documentation.
+const (
+	Indices Format = iota
+	CSV
+	Bits
+	Hex
+	NDJSON
+	Binary
+)
+
+var formatNames = [...]string{
+	Indices: "indices",
+	CSV:     "csv",
+	Bits:    "bits",
+	Hex:     "hex",
+	NDJSON:  "ndjson",
+	Binary:  "binary",
+}
+
+// String returns the name of the format, as accepted by ParseFormat.
+func (f Format) String() string {
+	if f < 0 || int(f) >= len(formatNames) {
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+	return formatNames[f]
+}
+
+// ParseFormat returns the format of the name: indices, csv, bits, hex, ndjson or binary.
+func ParseFormat(name string) (Format, error) {
+	for f, formatName := range formatNames {
+		if formatName == name {
+			return Format(f), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown format %q", name)
+}
+
+// WordWidth returns the number of bytes of a word in the Binary format: 8 bytes for every 64 elements, or part
+// thereof, and 8 bytes for n=0.
+func WordWidth(n uint) int {
+	return 8 * int(max(1, (n+63)/64))
+}
+
+// Writer writes combinations, of k out of n elements, in a Format. The combinations are buffered; call Flush
+// once done.
+type Writer struct {
+	out      *bufio.Writer
+	format   Format
+	n        uint
+	elements []uint // of the current combination
+	line     []byte // the formatted combination
+	rank     uint64
+	bigRank  *big.Int // the rank, once it overflows uint64
+}
+
+// NewWriter returns a Writer of combinations of elements in the range [0, n) to `w`, in the format.
+func NewWriter(w io.Writer, format Format, n uint) *Writer {
+	return &Writer{out: bufio.NewWriterSize(w, 64<<10), format: format, n: n}
+}
+
+// SetRank sets the rank of the next combination, written by the NDJSON format. Initially, it is 0; it
+// increases by one for every combination written.
+func (w *Writer) SetRank(rank *big.Int) {
+	if rank.IsUint64() {
+		w.rank, w.bigRank = rank.Uint64(), nil
+	} else {
+		w.bigRank = new(big.Int).Set(rank)
+	}
+}
+
+// appendRank appends the rank of the current combination
+func (w *Writer) appendRank(dst []byte) []byte {
+	if w.bigRank != nil {
+		return w.bigRank.Append(dst, 10)
+	}
+	return strconv.AppendUint(dst, w.rank, 10)
+}
+
+// incrementRank advances the rank to that of the next combination
+func (w *Writer) incrementRank() {
+	switch {
+	case w.bigRank != nil:
+		w.bigRank.Add(w.bigRank, big.NewInt(1))
+	case w.rank == ^uint64(0):
+		w.bigRank = new(big.Int).SetUint64(w.rank)
+		w.bigRank.Add(w.bigRank, big.NewInt(1))
+	default:
+		w.rank++
+	}
+}
+
+// write writes the combination of `w.elements`
+func (w *Writer) write() error {
+	elements := w.elements
+	for i, element := range elements {
+		if element >= w.n {
+			return fmt.Errorf("element %d out of range [0, %d)", element, w.n)
+		}
+		if i > 0 && element <= elements[i-1] {
+			return fmt.Errorf("elements %v not in strictly ascending order", elements)
+		}
+	}
+	line := w.line[:0]
+	switch w.format {
+	case Indices:
+		line = appendSeparated(line, elements, ' ')
+		line = append(line, '\n')
+	case CSV:
+		line = appendSeparated(line, elements, ',')
+		line = append(line, '\n')
+	case Bits:
+		start := len(line)
+		for range w.n {
+			line = append(line, '0')
+		}
+		for _, element := range elements {
+			line[start+int(element)] = '1'
+		}
+		line = append(line, '\n')
+	case Hex:
+		const hexDigits = "0123456789abcdef"
+		digits := int(max(1, (w.n+3)/4))
+		start := len(line)
+		for range digits {
+			line = append(line, 0)
+		}
+		for _, element := range elements {
+			// the most-significant digit first
+			line[start+digits-1-int(element/4)] |= 1 << (element % 4)
+		}
+		for i := start; i < len(line); i++ {
+			line[i] = hexDigits[line[i]]
+		}
+		line = append(line, '\n')
+	case NDJSON:
+		line = append(line, `{"rank":`...)
+		line = w.appendRank(line)
+		line = append(line, `,"elements":[`...)
+		line = appendSeparated(line, elements, ',')
+		line = append(line, "]}\n"...)
+	case Binary:
+		start := len(line)
+		for range WordWidth(w.n) {
+			line = append(line, 0)
+		}
+		for _, element := range elements {
+			line[start+int(element/8)] |= 1 << (element % 8)
+		}
+	default:
+		return fmt.Errorf("unknown format %v", w.format)
+	}
+	w.line = line
+	if _, err := w.out.Write(line); err != nil {
+		return err
+	}
+	w.incrementRank()
+	return nil
+}
+
+func appendSeparated(dst []byte, elements []uint, separator byte) []byte {
+	for i, element := range elements {
+		if i > 0 {
+			dst = append(dst, separator)
+		}
+		dst = strconv.AppendUint(dst, uint64(element), 10)
+	}
+	return dst
+}
+
+// WriteElements writes a combination.
+//
+// It is an error to pass elements that are not in strictly ascending order, or out of range [0, n).
+func (w *Writer) WriteElements(elements coollex.Elements) error {
+	w.elements = slices.AppendSeq(w.elements[:0], elements)
+	return w.write()
+}
+
+// WriteCombinations writes the combinations, and returns the number of combinations written.
+func (w *Writer) WriteCombinations(combinations coollex.Combinations) (uint64, error) {
+	count := uint64(0)
+	for elements := range combinations {
+		if err := w.WriteElements(elements); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// WriteWord64 writes a combination represented as in `ComputerWord64.Words()`.
+func (w *Writer) WriteWord64(word int64) error {
+	if w.format == Binary && w.n < 64 {
+		// fast path: the word as is
+		if uint64(word)>>w.n != 0 {
+			return fmt.Errorf("word %#x out of range for n %d", word, w.n)
+		}
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], uint64(word))
+		if _, err := w.out.Write(b[:]); err != nil {
+			return err
+		}
+		w.incrementRank()
+		return nil
+	}
+	elements := w.elements[:0]
+	for r := uint64(word); r != 0; r &= r - 1 {
+		elements = append(elements, uint(bits.TrailingZeros64(r)))
+	}
+	w.elements = elements
+	return w.write()
+}
+
+// WriteWords64 writes the combinations represented as in `ComputerWord64.Words()`, and returns the number of
+// combinations written.
+func (w *Writer) WriteWords64(words iter.Seq[int64]) (uint64, error) {
+	count := uint64(0)
+	for word := range words {
+		if err := w.WriteWord64(word); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// WriteWordBig writes a combination represented as in `ComputerWordBig.Words()`.
+func (w *Writer) WriteWordBig(word *big.Int) error {
+	if word.Sign() < 0 {
+		return fmt.Errorf("negative word %v", word)
+	}
+	elements := w.elements[:0]
+	for i, v := range word.Bits() {
+		for r := uint(v); r != 0; r &= r - 1 {
+			elements = append(elements, uint(i*bits.UintSize+bits.TrailingZeros(r)))
+		}
+	}
+	w.elements = elements
+	return w.write()
+}
+
+// WriteWordsBig writes the combinations represented as in `ComputerWordBig.Words()`, and returns the number
+// of combinations written.
+func (w *Writer) WriteWordsBig(words iter.Seq[*big.Int]) (uint64, error) {
+	count := uint64(0)
+	for word := range words {
+		if err := w.WriteWordBig(word); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *Writer) Flush() error {
+	return w.out.Flush()
+}
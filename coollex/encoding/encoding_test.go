@@ -0,0 +1,202 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package encoding
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/dastoikov/cool-lex-go/v2/coollex"
+)
+
+func TestFormats(t *testing.T) {
+	testCases := []struct {
+		format Format
+		expect string
+	}{
+		{Indices, "0 1\n1 2\n0 2\n1 3\n2 3\n0 3\n"},
+		{CSV, "0,1\n1,2\n0,2\n1,3\n2,3\n0,3\n"},
+		{Bits, "1100\n0110\n1010\n0101\n0011\n1001\n"},
+		{Hex, "3\n6\n5\na\nc\n9\n"},
+		{NDJSON, `{"rank":0,"elements":[0,1]}` + "\n" + `{"rank":1,"elements":[1,2]}` + "\n" +
+			`{"rank":2,"elements":[0,2]}` + "\n" + `{"rank":3,"elements":[1,3]}` + "\n" +
+			`{"rank":4,"elements":[2,3]}` + "\n" + `{"rank":5,"elements":[0,3]}` + "\n"},
+		{Binary, "\x03\x00\x00\x00\x00\x00\x00\x00\x06\x00\x00\x00\x00\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00" +
+			"\x0a\x00\x00\x00\x00\x00\x00\x00\x0c\x00\x00\x00\x00\x00\x00\x00\x09\x00\x00\x00\x00\x00\x00\x00"},
+	}
+	for _, tc := range testCases {
+		// the same output for combinations, 64-bit words, and big words
+		outputs := make([]bytes.Buffer, 3)
+		writers := []*Writer{NewWriter(&outputs[0], tc.format, 4), NewWriter(&outputs[1], tc.format, 4), NewWriter(&outputs[2], tc.format, 4)}
+		list, _ := coollex.NewLinkedList(4, 2)
+		word64, _ := coollex.NewComputerWord64(4, 2)
+		wordBig, _ := coollex.NewComputerWordBig(4, 2)
+		if _, err := writers[0].WriteCombinations(list.Combinations()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writers[1].WriteWords64(word64.Words()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writers[2].WriteWordsBig(wordBig.Words()); err != nil {
+			t.Fatal(err)
+		}
+		for i, w := range writers {
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if actual := outputs[i].String(); actual != tc.expect {
+				t.Fatalf("format %v, writer %d: expected %q, got %q", tc.format, i, tc.expect, actual)
+			}
+		}
+	}
+}
+
+func TestWideWords(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out, Hex, 130)
+	word := new(big.Int).SetBit(new(big.Int).SetBit(new(big.Int), 129, 1), 3, 1)
+	if err := w.WriteWordBig(word); err != nil {
+		t.Fatal(err)
+	}
+	w.Flush()
+	if expect := "200000000000000000000000000000008\n"; out.String() != expect {
+		t.Fatalf("expected %q, got %q", expect, out.String())
+	}
+
+	out.Reset()
+	w = NewWriter(&out, Binary, 130)
+	if err := w.WriteElements(slices.Values([]uint{0, 64, 129})); err != nil {
+		t.Fatal(err)
+	}
+	w.Flush()
+	expect := make([]byte, WordWidth(130))
+	expect[0], expect[8], expect[16] = 1, 1, 2
+	if !bytes.Equal(expect, out.Bytes()) {
+		t.Fatalf("expected %v, got %v", expect, out.Bytes())
+	}
+}
+
+func TestWord64Largest(t *testing.T) {
+	// n=63, the largest for ComputerWord64: the words round-trip, and match the words of the elements
+	const n, k = 63, 2
+	var words, elements bytes.Buffer
+	wordsWriter, elementsWriter := NewWriter(&words, Binary, n), NewWriter(&elements, Binary, n)
+	word64, _ := coollex.NewComputerWord64(n, k)
+	expect := slices.Collect(word64.Words())
+	if _, err := wordsWriter.WriteWords64(slices.Values(expect)); err != nil {
+		t.Fatal(err)
+	}
+	list, _ := coollex.NewLinkedList(n, k)
+	if _, err := elementsWriter.WriteCombinations(list.Combinations()); err != nil {
+		t.Fatal(err)
+	}
+	wordsWriter.Flush()
+	elementsWriter.Flush()
+	if !bytes.Equal(words.Bytes(), elements.Bytes()) {
+		t.Fatal("expected the same bytes for words and elements")
+	}
+	var actual []int64
+	for b := words.Bytes(); len(b) > 0; b = b[8:] {
+		actual = append(actual, int64(binary.LittleEndian.Uint64(b)))
+	}
+	if !slices.Equal(actual, expect) {
+		t.Fatalf("expected %d words, got %d", len(expect), len(actual))
+	}
+	if err := wordsWriter.WriteWord64(math.MinInt64); err == nil {
+		t.Fatal("error is expected for a word out of range")
+	}
+}
+
+func TestSetRank(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out, NDJSON, 3)
+	w.SetRank(new(big.Int).SetUint64(^uint64(0)))
+	w.WriteElements(slices.Values([]uint{0}))
+	w.WriteElements(slices.Values([]uint{1}))
+	w.Flush()
+	expect := `{"rank":18446744073709551615,"elements":[0]}` + "\n" + `{"rank":18446744073709551616,"elements":[1]}` + "\n"
+	if out.String() != expect {
+		t.Fatalf("expected %q, got %q", expect, out.String())
+	}
+}
+
+func TestErrors(t *testing.T) {
+	w := NewWriter(io.Discard, Bits, 3)
+	if err := w.WriteElements(slices.Values([]uint{0, 3})); err == nil {
+		t.Fatal("error is expected for an out-of-range element")
+	}
+	if err := w.WriteWord64(8); err == nil {
+		t.Fatal("error is expected for an out-of-range word")
+	}
+	if err := NewWriter(io.Discard, Binary, 3).WriteWord64(8); err == nil {
+		t.Fatal("error is expected for an out-of-range word")
+	}
+	if err := w.WriteWordBig(big.NewInt(-1)); err == nil {
+		t.Fatal("error is expected for a negative word")
+	}
+	if err := NewWriter(io.Discard, Format(42), 3).WriteWord64(1); err == nil {
+		t.Fatal("error is expected for an unknown format")
+	}
+	if _, err := ParseFormat("none"); err == nil {
+		t.Fatal("error is expected for an unknown format")
+	}
+	for f := range Binary + 1 {
+		if parsed, err := ParseFormat(f.String()); err != nil || parsed != f {
+			t.Fatalf("format %v: parsed as %v, %v", f, parsed, err)
+		}
+	}
+}
+
+func TestInvalidElements(t *testing.T) {
+	for f := range Binary + 1 {
+		var out bytes.Buffer
+		w := NewWriter(&out, f, 10)
+		for _, elements := range [][]uint{{100, 1}, {3, 1}, {2, 2}, {12, 14}, {1, 10}} {
+			if err := w.WriteElements(slices.Values(elements)); err == nil {
+				t.Fatalf("%v: error is expected for elements %v", f, elements)
+			}
+		}
+		if out.Len() != 0 {
+			t.Fatalf("%v: expected nothing written, got %q", f, out.Bytes())
+		}
+	}
+}
+
+func TestNoAllocations(t *testing.T) {
+	for f := range Binary + 1 {
+		w := NewWriter(io.Discard, f, 100)
+		word := new(big.Int).SetBit(new(big.Int).SetBit(new(big.Int), 99, 1), 3, 1)
+		if allocs := testing.AllocsPerRun(100, func() {
+			w.WriteWord64(0x0f0f)
+			w.WriteWordBig(word)
+		}); allocs != 0 {
+			t.Fatalf("format %v: %v allocations per combination", f, allocs)
+		}
+	}
+}
+
+func BenchmarkWriteWords64(b *testing.B) {
+	for f := range Binary + 1 {
+		b.Run(f.String(), func(b *testing.B) {
+			w := NewWriter(io.Discard, f, 40)
+			for range b.N {
+				word, _ := coollex.NewComputerWord64(40, 3)
+				w.WriteWords64(word.Words())
+			}
+		})
+	}
+}
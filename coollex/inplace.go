@@ -0,0 +1,84 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import "iter"
+
+// InPlace rearranges a slice of n items, in place, for each combination of k out of the n elements in Cool-lex
+// order, so that the first k items are the ones selected for the combination, the element i being the item
+// initially at index i. It is intended for processing heavy items without copying them into a new slice for
+// each combination.
+//
+// Rather than rotating a prefix of the items, which would move up to n items, each successor is applied
+// looplessly by at most two swaps, see Array. The order of the items within the first k, and within the
+// others, is unspecified.
+type InPlace[T any] struct {
+	array    Array // the combinations
+	items    []T
+	k        uint
+	position []uint // of the item of each element, in `items`
+}
+
+// swap deselects the element `out` and selects the element `in`, swapping their items.
+// Precondition: `out` is selected, and `in` is not, or they are the same.
+func (inPlace *InPlace[T]) swap(out, in uint) {
+	i, j := inPlace.position[out], inPlace.position[in]
+	inPlace.items[i], inPlace.items[j] = inPlace.items[j], inPlace.items[i]
+	inPlace.position[out], inPlace.position[in] = j, i
+}
+
+// hasNext reports whether more combinations are available
+func (inPlace *InPlace[T]) hasNext() bool {
+	return inPlace.array.hasNext()
+}
+
+// next advances to the next combination in cool-lex order, applying to the items the changes of Array.next
+func (inPlace *InPlace[T]) next() {
+	b, x, y := inPlace.array.b, inPlace.array.x, inPlace.array.y
+	if x+1 < uint(len(b)) {
+		inPlace.swap(x, y)
+		if !b[x+1] {
+			inPlace.swap(0, x+1)
+		}
+	}
+	inPlace.array.next()
+}
+
+// Slices returns an iterator over the generated combinations, each represented by the first k items of the
+// caller-provided slice, rearranged in place.
+//
+// Note: the slice shares the items of the caller-provided slice, which are rearranged between iterations.
+func (inPlace *InPlace[T]) Slices() iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for inPlace.hasNext() && yield(inPlace.items[:inPlace.k]) {
+			inPlace.next()
+		}
+	}
+}
+
+// NewInPlace returns a generator that rearranges `items` in place for each combination of k out of
+// n=len(items) elements in Cool-lex order, see InPlace. Initially, the first k items are the first combination.
+//
+// It is an error to pass arguments such that len(items) < k.
+func NewInPlace[T any](items []T, k uint) (InPlace[T], error) {
+	n := uint(len(items))
+	array, err := NewArray(n, k)
+	if err != nil {
+		return InPlace[T]{}, err
+	}
+	position := make([]uint, n)
+	for i := range position {
+		position[i] = uint(i)
+	}
+	return InPlace[T]{array: array, items: items, k: k, position: position}, nil
+}
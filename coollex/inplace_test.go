@@ -0,0 +1,88 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInPlace(t *testing.T) {
+	for _, tc := range []struct{ n, k uint }{{1, 1}, {5, 5}, {9, 1}, {10, 4}, {12, 6}, {7, 6}} {
+		g, _ := NewArray(tc.n, tc.k)
+		all := collect(g.Combinations())
+		items := make([]string, tc.n)
+		for i := range items {
+			items[i] = string(rune('a' + i))
+		}
+		original := slices.Clone(items)
+		inPlace, err := NewInPlace(items, tc.k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		i := 0
+		for selected := range inPlace.Slices() {
+			var expect []string
+			for _, element := range all[i] {
+				expect = append(expect, original[element])
+			}
+			actual := slices.Sorted(slices.Values(selected))
+			if !slices.Equal(actual, expect) {
+				t.Fatalf("n=%d, k=%d, combination %d: expected %v, got %v", tc.n, tc.k, i, expect, actual)
+			}
+			if sorted := slices.Sorted(slices.Values(items)); !slices.Equal(sorted, original) {
+				t.Fatalf("n=%d, k=%d: expected a permutation of %v, got %v", tc.n, tc.k, original, items)
+			}
+			i++
+		}
+		if i != len(all) {
+			t.Fatalf("n=%d, k=%d: expected %d combinations, got %d", tc.n, tc.k, len(all), i)
+		}
+	}
+}
+
+func TestInPlaceResume(t *testing.T) {
+	const n, k = 10, 3
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	g, _ := NewArray(n, k)
+	all := collect(g.Combinations())
+	inPlace, _ := NewInPlace(items, k)
+	i := 0
+	for range inPlace.Slices() {
+		if i == 50 {
+			break
+		}
+		i++
+	}
+	for selected := range inPlace.Slices() {
+		if actual := slices.Sorted(slices.Values(selected)); !slices.Equal(actual, []int{int(all[i][0]), int(all[i][1]), int(all[i][2])}) {
+			t.Fatalf("combination %d: expected %v, got %v", i, all[i], actual)
+		}
+		i++
+	}
+	if i != len(all) {
+		t.Fatalf("expected %d combinations, got %d", len(all), i)
+	}
+}
+
+func TestInPlaceNoCombinations(t *testing.T) {
+	inPlace, err := NewInPlace([]int{1, 2, 3}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range inPlace.Slices() {
+		t.Fatal("expected no combinations for k=0")
+	}
+	if _, err := NewInPlace([]int{1, 2, 3}, 4); err == nil {
+		t.Fatal("error is expected for n < k")
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// limited is implemented by the generators that can stop before the last combination, as for an interval, or
+// wrap around past it, as cyclic ones. The generators check their limits only when they have any, so that the
+// iteration of the generators without limits does not pay for them.
+type limited interface {
+	// limits returns the combination to stop at, not yielded, or nil to stop past the last combination; and
+	// whether the generator is to wrap around past the last combination, to the first one, before stopping
+	limits() (stop []uint, wrap bool)
+	// wrapped records that the generator wrapped around past the last combination
+	wrapped()
+}
+
+// limitsOf returns the limits of the generator, see limited; none unless it implements limited
+func limitsOf(g repositionable) ([]uint, bool) {
+	if l, ok := g.(limited); ok {
+		return l.limits()
+	}
+	return nil, false
+}
+
+// interval returns the elements of the combinations, of k out of n elements, at the ranks `start`, or nil if
+// the interval [start, end) is empty, and `end`, or nil if `end` is C(n,k).
+//
+// It is an error to pass arguments such that n < k, k = 0, or the interval is out of range [0, C(n,k)].
+func interval(n, k uint, start, end *big.Int) ([]uint, []uint, error) {
+	if n < k {
+		return nil, nil, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	if k == 0 {
+		return nil, nil, fmt.Errorf("no combinations for k=0")
+	}
+	count := binomial(n, k)
+	if start.Sign() < 0 || start.Cmp(end) > 0 || end.Cmp(count) > 0 {
+		return nil, nil, fmt.Errorf("interval [%v, %v) out of range [0, %v]", start, end, count)
+	}
+	if start.Cmp(end) == 0 {
+		return nil, nil, nil
+	}
+	first, _ := UnrankBig(start, n, k) // no error for a rank in range
+	var stop []uint
+	if end.Cmp(count) < 0 {
+		stop, _ = UnrankBig(end, n, k)
+	}
+	return first, stop, nil
+}
+
+// intervalRanks returns the ranks of the combinations of the elements `start` and `end`, or C(n,k) for no
+// `end`, where k=len(start).
+//
+// It is an error to pass out-of-range elements, or combinations of different k.
+func intervalRanks(n uint, start, end []uint) (*big.Int, *big.Int, error) {
+	if end != nil && len(end) != len(start) {
+		return nil, nil, fmt.Errorf("combinations of %d and %d elements", len(start), len(end))
+	}
+	startRank, err := RankBig(start, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	if end == nil {
+		return startRank, binomial(n, uint(len(start))), nil
+	}
+	endRank, err := RankBig(end, n)
+	return startRank, endRank, err
+}
+
+// remaining returns the number of combinations that the generator is yet to yield
+func remaining(g repositionable) *big.Int {
+	if !g.current() {
+		return new(big.Int)
+	}
+	n, elements := g.size(), slices.Collect(g.Elements())
+	rank, _ := RankBig(elements, n) // no error for a combination of a generator
+	count := binomial(n, uint(len(elements)))
+	end := count
+	stop, wrap := limitsOf(g)
+	if stop != nil {
+		end, _ = RankBig(stop, n)
+	}
+	if wrap {
+		end.Add(end, count)
+	}
+	return end.Sub(end, rank)
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+type bounded interface {
+	skipper
+	count() *big.Int
+}
+
+type bounded64 struct {
+	skipper
+	c func() uint64
+}
+
+func (b bounded64) count() *big.Int {
+	return new(big.Int).SetUint64(b.c())
+}
+
+type boundedBig struct {
+	skipper
+	c func() *big.Int
+}
+
+func (b boundedBig) count() *big.Int {
+	return b.c()
+}
+
+var intervals = map[string]func(n, k, start, end uint) (bounded, error){
+	"ComputerWord32": func(n, k, start, end uint) (bounded, error) {
+		g, err := NewComputerWord32Range(n, k, start, end)
+		return bounded64{&g, g.Count}, err
+	},
+	"ComputerWord64": func(n, k, start, end uint) (bounded, error) {
+		g, err := NewComputerWord64Range(n, k, start, end)
+		return bounded64{&g, g.Count}, err
+	},
+	"ComputerWordBig": func(n, k, start, end uint) (bounded, error) {
+		g, err := NewComputerWordBigRange(n, k, big.NewInt(int64(start)), big.NewInt(int64(end)))
+		return boundedBig{&g, g.Count}, err
+	},
+	"LinkedList": func(n, k, start, end uint) (bounded, error) {
+		g, err := NewLinkedListRange(n, k, big.NewInt(int64(start)), big.NewInt(int64(end)))
+		return boundedBig{&g, g.Count}, err
+	},
+}
+
+var betweens = map[string]func(n uint, start, end []uint) (bounded, error){
+	"ComputerWord32": func(n uint, start, end []uint) (bounded, error) {
+		g, err := NewComputerWord32Between(n, start, end)
+		return bounded64{&g, g.Count}, err
+	},
+	"ComputerWord64": func(n uint, start, end []uint) (bounded, error) {
+		g, err := NewComputerWord64Between(n, start, end)
+		return bounded64{&g, g.Count}, err
+	},
+	"ComputerWordBig": func(n uint, start, end []uint) (bounded, error) {
+		g, err := NewComputerWordBigBetween(n, start, end)
+		return boundedBig{&g, g.Count}, err
+	},
+	"LinkedList": func(n uint, start, end []uint) (bounded, error) {
+		g, err := NewLinkedListBetween(n, start, end)
+		return boundedBig{&g, g.Count}, err
+	},
+}
+
+func TestRange(t *testing.T) {
+	for _, tc := range []struct{ n, k uint }{{1, 1}, {5, 5}, {9, 1}, {10, 4}, {12, 6}} {
+		g, _ := NewComputerWord64(tc.n, tc.k)
+		all := collect(g.Combinations())
+		size := uint(len(all))
+		for name, newInterval := range intervals {
+			for _, r := range [][2]uint{{0, 0}, {0, 1}, {0, size}, {1, size}, {size / 3, size / 2}, {size - 1, size}, {size, size}} {
+				g, err := newInterval(tc.n, tc.k, r[0], r[1])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if count := g.count(); count.Cmp(big.NewInt(int64(r[1]-r[0]))) != 0 {
+					t.Fatalf("%s, n=%d, k=%d, [%d, %d): expected count %d, got %v", name, tc.n, tc.k, r[0], r[1], r[1]-r[0], count)
+				}
+				expect := all[r[0]:r[1]]
+				if actual := collect(g.Combinations()); !slices.EqualFunc(actual, expect, slices.Equal) {
+					t.Fatalf("%s, n=%d, k=%d, [%d, %d): expected %v, got %v", name, tc.n, tc.k, r[0], r[1], expect, actual)
+				}
+				if count := g.count(); count.Sign() != 0 {
+					t.Fatalf("%s: expected count 0 once ended, got %v", name, count)
+				}
+			}
+		}
+	}
+}
+
+func TestRangeWords(t *testing.T) {
+	all, _ := NewComputerWord64(10, 4)
+	expect := slices.Collect(all.Words())[20:150]
+	word, _ := NewComputerWord64Range(10, 4, 20, 150)
+	if actual := slices.Collect(word.Words()); !slices.Equal(actual, expect) {
+		t.Fatalf("expected %v, got %v", expect, actual)
+	}
+	wordBig, _ := NewComputerWordBigRange(10, 4, big.NewInt(20), big.NewInt(150))
+	i := 0
+	for actual := range wordBig.Words() {
+		if actual.Int64() != expect[i] {
+			t.Fatalf("word %d: expected %#x, got %#x", i, expect[i], actual)
+		}
+		i++
+	}
+	if i != len(expect) {
+		t.Fatalf("expected %d words, got %d", len(expect), i)
+	}
+}
+
+func TestRangeResume(t *testing.T) {
+	const n, k, start, end = 12, 5, 100, 300
+	g, _ := NewComputerWord64(n, k)
+	all := collect(g.Combinations())
+	for name, newInterval := range intervals {
+		g, _ := newInterval(n, k, start, end)
+		i := 0
+		for range g.Combinations() {
+			if i == 50 {
+				break
+			}
+			i++
+		}
+		if count := g.count(); count.Cmp(big.NewInt(end-start-50)) != 0 {
+			t.Fatalf("%s: expected count %d, got %v", name, end-start-50, count)
+		}
+		expect := all[start+50 : end]
+		if actual := collect(g.Combinations()); !slices.EqualFunc(actual, expect, slices.Equal) {
+			t.Fatalf("%s: expected %d combinations, got %d", name, len(expect), len(actual))
+		}
+	}
+}
+
+func TestRangeAdvance(t *testing.T) {
+	const n, k, start, end = 13, 6, 200, 1500
+	g, _ := NewComputerWord64(n, k)
+	all := collect(g.Combinations())[start:end]
+	for name, newInterval := range intervals {
+		for _, m := range []uint64{0, 3, 64, 65, 900, end - start - 1, end - start, 1 << 40} {
+			g, _ := newInterval(n, k, start, end)
+			g.Advance(m)
+			expect := all[min(m, uint64(len(all))):]
+			if actual := collect(g.Combinations()); !slices.EqualFunc(actual, expect, slices.Equal) {
+				t.Fatalf("%s: advanced by %d: expected %d combinations, got %d", name, m, len(expect), len(actual))
+			}
+		}
+		for _, m := range []uint64{1, 2, 7, 100} {
+			var expect [][]uint
+			for i := 0; i < len(all); i += int(m) {
+				expect = append(expect, all[i])
+			}
+			g, _ := newInterval(n, k, start, end)
+			if actual := collect(g.Stride(m)); !slices.EqualFunc(actual, expect, slices.Equal) {
+				t.Fatalf("%s: stride %d: expected %d combinations, got %d", name, m, len(expect), len(actual))
+			}
+		}
+	}
+}
+
+func TestRangeErrors(t *testing.T) {
+	for name, newInterval := range intervals {
+		for _, tc := range []struct{ n, k, start, end uint }{
+			{3, 4, 0, 0},
+			{5, 0, 0, 0},
+			{5, 2, 3, 2},
+			{5, 2, 0, 11},
+			{5, 2, 11, 11},
+		} {
+			if _, err := newInterval(tc.n, tc.k, tc.start, tc.end); err == nil {
+				t.Fatalf("%s: error is expected for %+v", name, tc)
+			}
+		}
+	}
+	if _, err := NewComputerWordBigRange(5, 2, big.NewInt(-1), big.NewInt(3)); err == nil {
+		t.Fatal("error is expected for a negative start")
+	}
+}
+
+func TestRangeLarge(t *testing.T) {
+	// intervals of more than 2^64-1 combinations
+	const n, k = 200, 100
+	count := binomial(n, k)
+	end := new(big.Int).Sub(count, big.NewInt(2))
+	var last [][]uint
+	for rank := new(big.Int).Sub(end, big.NewInt(3)); rank.Cmp(end) < 0; rank.Add(rank, bigOne) {
+		elements, _ := UnrankBig(rank, n, k)
+		last = append(last, elements)
+	}
+	word, _ := NewComputerWordBigRange(n, k, new(big.Int), end)
+	list, _ := NewLinkedListRange(n, k, new(big.Int), end)
+	for name, g := range map[string]bounded{"ComputerWordBig": boundedBig{&word, word.Count}, "LinkedList": boundedBig{&list, list.Count}} {
+		if g.count().Cmp(end) != 0 {
+			t.Fatalf("%s: expected %v combinations, got %v", name, end, g.count())
+		}
+		if err := g.AdvanceBig(new(big.Int).Sub(end, big.NewInt(3))); err != nil {
+			t.Fatal(err)
+		}
+		if actual := collect(g.Combinations()); !slices.EqualFunc(actual, last, slices.Equal) {
+			t.Fatalf("%s: expected %v, got %v", name, last, actual)
+		}
+	}
+
+	// from the first combination to the last one
+	first, _ := UnrankBig(new(big.Int), 100, 50)
+	between, err := NewComputerWordBigBetween(100, first, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := binomial(100, 50); between.Count().Cmp(expect) != 0 {
+		t.Fatalf("expected %v combinations, got %v", expect, between.Count())
+	}
+}
+
+func TestBetween(t *testing.T) {
+	const n, k = 11, 4
+	g, _ := NewComputerWord64(n, k)
+	all := collect(g.Combinations())
+	for name, newBetween := range betweens {
+		for _, r := range [][2]int{{0, 0}, {0, 1}, {3, 200}, {100, len(all) - 1}, {7, 7}} {
+			g, err := newBetween(n, all[r[0]], all[r[1]])
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect := all[r[0]:r[1]]
+			if actual := collect(g.Combinations()); !slices.EqualFunc(actual, expect, slices.Equal) {
+				t.Fatalf("%s, between %v and %v: expected %v, got %v", name, all[r[0]], all[r[1]], expect, actual)
+			}
+		}
+		// to the last combination, inclusive
+		g, _ := newBetween(n, all[17], nil)
+		if actual := collect(g.Combinations()); !slices.EqualFunc(actual, all[17:], slices.Equal) {
+			t.Fatalf("%s: expected %d combinations to the last one, got %d", name, len(all)-17, len(actual))
+		}
+		if _, err := newBetween(n, all[10], all[5]); err == nil {
+			t.Fatalf("%s: error is expected for an end that precedes the start", name)
+		}
+		if _, err := newBetween(n, []uint{0, 1}, []uint{0, 1, 2}); err == nil {
+			t.Fatalf("%s: error is expected for combinations of different k", name)
+		}
+		if _, err := newBetween(n, []uint{0, n}, nil); err == nil {
+			t.Fatalf("%s: error is expected for out-of-range elements", name)
+		}
+		if _, err := newBetween(n, nil, nil); err == nil {
+			t.Fatalf("%s: error is expected for no elements", name)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/dastoikov/cool-lex-go/v2/simplemath"
+)
+
+// KPermutations generates the k-permutations (arrangements) of n elements, that is, the ordered selections
+// of k out of n elements. The combinations are generated in Cool-lex order; the permutations of each
+// combination are generated in lexicographic order, before advancing to the next combination.
+//
+// The arrangements form a product space: the arrangement at rank `r` is the permutation at rank `r % k!`
+// of the combination at rank `r / k!`.
+type KPermutations struct {
+	n, k         uint
+	combinations Combinations
+	arrangement  []uint
+}
+
+// nextPermutation rearranges `p` into its lexicographic successor and reports whether there is one.
+// See The Art of Computer Programming, Vol. 4A, by Donald E. Knuth, 7.2.1.2, Algorithm L.
+func nextPermutation(p []uint) bool {
+	j := len(p) - 2
+	for j >= 0 && p[j] >= p[j+1] {
+		j--
+	}
+	if j < 0 {
+		return false
+	}
+	l := len(p) - 1
+	for p[j] >= p[l] {
+		l--
+	}
+	p[j], p[l] = p[l], p[j]
+	slices.Reverse(p[j+1:])
+	return true
+}
+
+// Slices returns an iterator over the generated arrangements, each represented as a slice of k elements.
+//
+// Note: the slice is reused between iterations and should only be used for reading.
+func (perm *KPermutations) Slices() iter.Seq[[]uint] {
+	return func(yield func([]uint) bool) {
+		for combination := range perm.combinations {
+			arrangement := perm.arrangement[:0]
+			for element := range combination {
+				arrangement = append(arrangement, element)
+			}
+			perm.arrangement = arrangement
+			for {
+				if !yield(arrangement) {
+					return
+				}
+				if !nextPermutation(arrangement) {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Arrangements returns an iterator over the generated arrangements.
+func (perm *KPermutations) Arrangements() iter.Seq[Elements] {
+	return func(yield func(Elements) bool) {
+		for arrangement := range perm.Slices() {
+			if !yield(slices.Values(arrangement)) {
+				return
+			}
+		}
+	}
+}
+
+// Count returns the number of arrangements, that is, n!/(n-k)!.
+// Error is reported if numeric overflow occurs.
+func (perm *KPermutations) Count() (uint, error) {
+	if perm.k == 0 {
+		return 0, nil
+	}
+	return simplemath.MulRange(perm.n, perm.n-perm.k+1)
+}
+
+// Rank returns the position of `arrangement` in the order in which the arrangements are generated.
+//
+// It is an error to pass an arrangement of other than k distinct elements in the range [0, n), or to rank
+// arrangements whose count overflows.
+func (perm *KPermutations) Rank(arrangement []uint) (uint, error) {
+	if uint(len(arrangement)) != perm.k {
+		return 0, fmt.Errorf("arrangement of %d elements, expected %d", len(arrangement), perm.k)
+	}
+	if _, err := perm.Count(); err != nil {
+		return 0, err
+	}
+	combination := slices.Sorted(slices.Values(arrangement))
+	r, err := Rank(combination, perm.n)
+	if err != nil {
+		return 0, err
+	}
+	// Lehmer code of the arrangement, in the factorial number system
+	var p uint
+	for i, element := range arrangement {
+		smaller := uint(0)
+		for _, other := range arrangement[i+1:] {
+			if other < element {
+				smaller++
+			}
+		}
+		p = p*(perm.k-uint(i)) + smaller
+	}
+	kFactorial, _ := simplemath.Factorial(perm.k)
+	return r*kFactorial + p, nil
+}
+
+// Unrank returns the arrangement at position `rank` in the order in which the arrangements are generated.
+//
+// It is an error to pass `rank` out of range, or to unrank arrangements whose count overflows.
+func (perm *KPermutations) Unrank(rank uint) ([]uint, error) {
+	count, err := perm.Count()
+	if err != nil {
+		return nil, err
+	}
+	if rank >= count {
+		return nil, fmt.Errorf("rank %d out of range [0, %d)", rank, count)
+	}
+	kFactorial, _ := simplemath.Factorial(perm.k)
+	combination, err := unrank(rank/kFactorial, perm.n, perm.k, nil)
+	if err != nil {
+		return nil, err
+	}
+	// decode the Lehmer code, picking the elements from the combination
+	p := rank % kFactorial
+	arrangement := make([]uint, perm.k)
+	for i := range arrangement {
+		kFactorial /= perm.k - uint(i)
+		j := p / kFactorial
+		p %= kFactorial
+		arrangement[i] = combination[j]
+		combination = slices.Delete(combination, int(j), int(j)+1)
+	}
+	return arrangement, nil
+}
+
+// NewKPermutations returns a generator of the k-permutations of n elements, composing combinations in
+// Cool-lex order with the permutations of each combination. The combinations are generated by ComputerWord64
+// for n<64, and by ComputerWordBig otherwise.
+//
+// n: number of elements to arrange; n>=k must hold.
+//
+// k: number of elements in each arrangement.
+//
+// It is an error to pass arguments such that n < k.
+func NewKPermutations(n, k uint) (KPermutations, error) {
+	if n < k {
+		return KPermutations{}, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	var combinations Combinations
+	if n < 64 {
+		word, _ := NewComputerWord64(n, k)
+		combinations = word.Combinations()
+	} else {
+		word, _ := NewComputerWordBig(n, k)
+		combinations = word.Combinations()
+	}
+	return KPermutations{
+		n:            n,
+		k:            k,
+		combinations: combinations,
+		arrangement:  make([]uint, 0, k),
+	}, nil
+}
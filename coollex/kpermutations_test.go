@@ -0,0 +1,75 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestKPermutations(t *testing.T) {
+	testCases := []struct{ n, k uint }{
+		{1, 1}, {4, 1}, {4, 4}, {5, 3}, {7, 2}, {70, 2},
+	}
+	for _, tc := range testCases {
+		perm, err := NewKPermutations(tc.n, tc.k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		count, err := perm.Count()
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen := make(map[string]bool)
+		expectRank := uint(0)
+		for arrangement := range perm.Slices() {
+			key := fmt.Sprint(arrangement)
+			if seen[key] {
+				t.Fatalf("duplicate arrangement %v, for n %d and k %d", arrangement, tc.n, tc.k)
+			}
+			seen[key] = true
+
+			r, err := perm.Rank(arrangement)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r != expectRank {
+				t.Fatalf("rank: expected %d, got %d, for %v, n %d and k %d", expectRank, r, arrangement, tc.n, tc.k)
+			}
+			actual, err := perm.Unrank(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(arrangement, actual) {
+				t.Fatalf("unrank: expected %v, got %v, for rank %d", arrangement, actual, r)
+			}
+			expectRank++
+		}
+		if expectRank != count {
+			t.Fatalf("number of arrangements: expected %d, got %d, for n %d and k %d", count, expectRank, tc.n, tc.k)
+		}
+	}
+}
+
+func TestKPermutationsNone(t *testing.T) {
+	perm, _ := NewKPermutations(5, 0)
+	for arrangement := range perm.Arrangements() {
+		t.Fatalf("arrangement %v found for k 0", slices.Collect(arrangement))
+	}
+	if count, _ := perm.Count(); count != 0 {
+		t.Fatalf("count: expected 0, got %d", count)
+	}
+	if _, err := NewKPermutations(2, 3); err == nil {
+		t.Fatal("error is expected for n<k")
+	}
+}
@@ -15,11 +15,16 @@ package coollex
 import (
 	"fmt"
 	"iter"
+	"math/big"
 )
 
 type node struct {
 	value bool
 	next  *node
+
+	// the following apply to value-true nodes only
+	nextTrue *node // the next value-true node
+	delta    uint  // distance from the previous value-true node, or the index plus one for the first one
 }
 
 // valueTrueNodes returns an iterator that yields the indices of nodes with a true value
@@ -41,11 +46,21 @@ func (n *node) valueTrueNodes() iter.Seq[uint] {
 }
 
 // LinkedList implements the LinkedList algorithm from the paper, see section 3.2. Iterative Algorithms, page 8
+//
+// Additionally, the value-true nodes are linked in a secondary list, each node storing its distance from the
+// previous one, so that the elements of a combination are yielded in O(k).
 type LinkedList struct {
 	// b, x are named as found in the research paper
 	// b - the head of the list; this is the node with the greatest "index"
 	// x - the first node, tail-to-head, whose value is 1 and whose predecessor's value is 0
 	b, x *node
+	// the head of the list of value-true nodes
+	ones *node
+	// whether all combinations have been yielded
+	ended bool
+	// of the lists of an interval, or cyclic ones: the combination to stop at, and the one to stop at once
+	// wrapped around past the last combination; nil for none
+	stop, cycle []uint
 }
 
 // newLinkedList creates a new LinkedList with the specified number of 0-bits (s) and number of 1-bits (t; precondition: t>0).
@@ -57,18 +72,21 @@ func newLinkedList(s, t uint) LinkedList {
 
 	b := &nodes[0]
 	b.value = true
+	b.delta = 1
 	x := &nodes[t-1]
 
 	prev := b
 	i := uint(1)
 	for ; i < t; i++ {
+		prev.nextTrue = &nodes[i]
 		prev = link(prev, &nodes[i])
 		prev.value = true
+		prev.delta = 1
 	}
 	for ; i < size; i++ {
 		prev = link(prev, &nodes[i])
 	}
-	return LinkedList{b, x}
+	return LinkedList{b: b, x: x, ones: b}
 }
 
 //go:inline
@@ -88,19 +106,22 @@ func newLinkedListNoNewVars(s, t uint) LinkedList {
 	t--
 	b := &nodes[t]
 	b.value = true
+	b.delta = 1
 
 	prev := b
 	for t > s {
 		t--
+		prev.nextTrue = &nodes[t]
 		prev = link(prev, &nodes[t])
 		prev.value = true
+		prev.delta = 1
 	}
 	x := prev
 	for t > 0 {
 		t--
 		prev = link(prev, &nodes[t])
 	}
-	return LinkedList{b, x}
+	return LinkedList{b: b, x: x, ones: b}
 }
 
 // hasNext reports whether more combinations are available
@@ -115,6 +136,20 @@ func (list *LinkedList) next() {
 	y.next = list.b
 	list.b = y
 
+	// y moves to the head, shifting the nodes that preceded it by one position
+	if y.value {
+		// y precedes the other value-true nodes; it immediately followed x, which takes its position
+		list.x.nextTrue = y.nextTrue
+		y.nextTrue = list.ones
+		y.delta = 1
+		list.ones = y
+	} else {
+		list.ones.delta++
+		if z := list.x.nextTrue; z != nil {
+			z.delta--
+		}
+	}
+
 	if !list.b.value && list.b.next.value {
 		list.x = list.b.next
 	}
@@ -122,7 +157,16 @@ func (list *LinkedList) next() {
 
 // Elements returns an iterator over the elements selected for the current combination.
 func (list *LinkedList) Elements() Elements {
-	return list.b.valueTrueNodes()
+	ones := list.ones
+	return func(yield func(uint) bool) {
+		var i uint
+		for curr := ones; curr != nil; curr = curr.nextTrue {
+			i += curr.delta
+			if !yield(i - 1) {
+				break
+			}
+		}
+	}
 }
 
 // Combinations returns an iterator over the generated combinations.
@@ -133,8 +177,16 @@ func (list *LinkedList) Combinations() Combinations {
 	}
 	return func(yield func(Elements) bool) {
 		//the algorithm is initially positioned at the first combination
-		for yield(list.Elements()) && list.hasNext() {
-			list.next()
+		if list.stop != nil || list.cycle != nil {
+			for !list.ended && yield(list.Elements()) {
+				list.step()
+			}
+			return
+		}
+		for !list.ended && yield(list.Elements()) {
+			if list.ended = !list.hasNext(); !list.ended {
+				list.next()
+			}
 		}
 	}
 }
@@ -156,3 +208,167 @@ func NewLinkedList(n, k uint) (LinkedList, error) {
 	}
 	return newLinkedList(n-k, k), nil
 }
+
+func (list *LinkedList) current() bool {
+	return list.b != nil && !list.ended
+}
+
+func (list *LinkedList) step() {
+	if list.ended = !list.hasNext(); !list.ended {
+		list.next()
+	} else if list.cycle != nil {
+		list.rewind()
+	}
+	if list.stop != nil && !list.ended {
+		list.ended = list.at(list.stop)
+	}
+}
+
+// at reports whether the list is positioned at the combination of the elements
+func (list *LinkedList) at(elements []uint) bool {
+	i := 0
+	for element := range list.Elements() {
+		if element != elements[i] {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+func (list *LinkedList) wrapped() {
+	list.stop, list.cycle = list.cycle, nil
+}
+
+// rewind positions the list at the first combination, following the last one
+func (list *LinkedList) rewind() {
+	var first []uint
+	for range list.Elements() {
+		first = append(first, uint(len(first)))
+	}
+	list.reposition(first)
+	list.wrapped()
+}
+
+func (list *LinkedList) size() uint {
+	var n uint
+	for curr := list.b; curr != nil; curr = curr.next {
+		n++
+	}
+	return n
+}
+
+func (list *LinkedList) reposition(elements []uint) {
+	var nodes []*node
+	for curr := list.b; curr != nil; curr = curr.next {
+		nodes = append(nodes, curr)
+	}
+	// relink the nodes, the i-th one at position i
+	var prev *node
+	var prevPosition uint
+	next := 0
+	list.ones = nil
+	for i, curr := range nodes {
+		curr.next, curr.nextTrue = nil, nil
+		if i+1 < len(nodes) {
+			curr.next = nodes[i+1]
+		}
+		curr.value = next < len(elements) && elements[next] == uint(i)
+		if !curr.value {
+			continue
+		}
+		next++
+		if prev == nil {
+			list.ones, curr.delta = curr, uint(i)+1
+		} else {
+			prev.nextTrue, curr.delta = curr, uint(i)-prevPosition
+		}
+		prev, prevPosition = curr, uint(i)
+	}
+	list.b, list.x = nodes[0], nodes[firstOfRise(elements)]
+	list.ended = false
+}
+
+func (list *LinkedList) end() {
+	list.ended = true
+	list.cycle = nil
+}
+
+// Advance skips `m` combinations, see ComputerWord64.Advance.
+func (list *LinkedList) Advance(m uint64) {
+	advance(list, m)
+}
+
+// AdvanceBig skips `m` combinations, see ComputerWord64.Advance.
+//
+// It is an error to pass a negative `m`.
+func (list *LinkedList) AdvanceBig(m *big.Int) error {
+	return advanceBig(list, m)
+}
+
+// Stride returns an iterator over every m-th generated combination, see ComputerWord64.Stride.
+func (list *LinkedList) Stride(m uint64) Combinations {
+	return stride(list, m)
+}
+
+func (list *LinkedList) limits() ([]uint, bool) {
+	if list.cycle != nil {
+		return list.cycle, true
+	}
+	return list.stop, false
+}
+
+// Count returns the number of combinations that the generator is yet to yield, see ComputerWord64.Count.
+func (list *LinkedList) Count() *big.Int {
+	return remaining(list)
+}
+
+// NewLinkedListRange returns a combinations generator, see NewLinkedList, that yields the combinations at
+// positions in the interval [start, end) in Cool-lex order.
+//
+// It is an error to pass arguments such that n < k, k = 0, or the interval is out of range [0, C(n,k)].
+func NewLinkedListRange(n, k uint, start, end *big.Int) (LinkedList, error) {
+	first, stop, err := interval(n, k, start, end)
+	if err != nil {
+		return LinkedList{}, err
+	}
+	list := newLinkedList(n-k, k)
+	if first == nil {
+		list.ended = true
+		return list, nil
+	}
+	list.reposition(first)
+	list.stop = stop
+	return list, nil
+}
+
+// NewLinkedListBetween returns a combinations generator, see NewLinkedList, that yields the combinations from
+// the combination of the elements `start`, inclusive, to the combination of the elements `end`, exclusive, see
+// NewComputerWord64Between.
+//
+// It is an error to pass arguments such that the combinations are of different or no elements, out-of-range
+// elements, or `end` precedes `start`.
+func NewLinkedListBetween(n uint, start, end []uint) (LinkedList, error) {
+	startRank, endRank, err := intervalRanks(n, start, end)
+	if err != nil {
+		return LinkedList{}, err
+	}
+	return NewLinkedListRange(n, uint(len(start)), startRank, endRank)
+}
+
+// NewLinkedListCyclic returns a combinations generator, see NewLinkedList, that yields all the C(n,k)
+// combinations in Cool-lex order, starting at the combination at position `rank`, see NewComputerWord64Cyclic.
+//
+// It is an error to pass arguments such that n < k, k = 0, or `rank` is not in the range [0, C(n,k)).
+func NewLinkedListCyclic(n, k uint, rank *big.Int) (LinkedList, error) {
+	elements, err := UnrankBig(rank, n, k)
+	if err != nil {
+		return LinkedList{}, err
+	}
+	list := newLinkedList(n-k, k)
+	list.reposition(elements)
+	if rank.Sign() > 0 {
+		list.cycle = elements
+	}
+	return list, nil
+}
@@ -26,6 +26,17 @@ func BenchmarkLinkedList(b *testing.B) {
 	}
 }
 
+func BenchmarkLinkedListElements(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		list, _ := NewLinkedList(benchSparseN, benchSparseK)
+		for combination := range list.Combinations() {
+			for e := range combination {
+				_ = e
+			}
+		}
+	}
+}
+
 // Number of nodes for benchmarking newLinkedList.
 // That is, the number of elements to choose from, or n.
 const (
@@ -109,4 +120,37 @@ func TestLinkedList(t *testing.T) {
 		list, err := NewLinkedList(n, k)
 		return &list, err
 	})
+	testCoollex(t, func(n, k uint) (coollexAlgorithm, error) {
+		list, err := NewLinkedList(n, k)
+		if err == nil && k > 0 {
+			list = newLinkedListNoNewVars(n-k, k)
+		}
+		return &list, err
+	})
+}
+
+// TestLinkedListNoLastCombinationAgain verifies that, once ended, the list no longer yields its last
+// combination again, as it used to when Combinations was called again.
+func TestLinkedListNoLastCombinationAgain(t *testing.T) {
+	list, _ := NewLinkedList(5, 2)
+	if count := len(slices.Collect(list.Combinations())); count != 10 {
+		t.Fatalf("expected 10 combinations, got %d", count)
+	}
+	for combination := range list.Combinations() {
+		t.Fatalf("expected no combinations once ended, got %v", slices.Collect(combination))
+	}
+}
+
+// TestLinkedListElements verifies that the elements yielded by following the value-true nodes match those
+// found by traversing the whole list.
+func TestLinkedListElements(t *testing.T) {
+	for _, tc := range []struct{ n, k uint }{{1, 1}, {2, 1}, {5, 5}, {12, 6}, {15, 7}, {40, 2}, {40, 38}} {
+		list, _ := NewLinkedList(tc.n, tc.k)
+		for combination := range list.Combinations() {
+			expect := slices.Collect(list.b.valueTrueNodes())
+			if actual := slices.Collect(combination); !slices.Equal(expect, actual) {
+				t.Fatalf("expected %v, got %v, for n %d and k %d", expect, actual, tc.n, tc.k)
+			}
+		}
+	}
 }
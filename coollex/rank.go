@@ -0,0 +1,256 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// Ranking follows the recursive definition of the cool-lex order. For a binary string with `s` 0-bits and
+// `t` 1-bits, and with position 0 written first:
+//
+//	C(s,t) = C(s-1,t)·0, rotate(C(s,t-1))·1
+//
+// where `rotate` moves the first string of a list to its end. Hence, the first C(s+t-1,t) strings end with
+// a 0-bit, and the rank of a string ending with a 1-bit is derived from the rank of its prefix, shifted by one
+// position (cyclically).
+
+// mulDiv returns a*b/c, using a double-width intermediate product.
+// Precondition: the quotient fits in uint.
+func mulDiv(a, b, c uint) uint {
+	hi, lo := bits.Mul(a, b)
+	q, _ := bits.Div(hi, lo, c)
+	return q
+}
+
+// numComb returns C(n,k), computed with double-width intermediate products, so that it only reports an error
+// if C(n,k) itself overflows; unlike simplemath.NumComb, whose intermediate products may overflow.
+// Precondition: `k<=n`.
+func numComb(n, k uint) (uint, error) {
+	c, m := uint(1), min(k, n-k)
+	for i := uint(1); i <= m; i++ {
+		// c*(n-m+i)/i = C(n-m+i,i) is exact, and not less than the preceding ones, so it overflows if hi >= i
+		hi, lo := bits.Mul(c, n-m+i)
+		if hi >= i {
+			return 0, fmt.Errorf("C(%d,%d) overflows", n, k)
+		}
+		c, _ = bits.Div(hi, lo, i)
+	}
+	return c, nil
+}
+
+// checkElements reports an error unless `elements` are in strictly ascending order and in the range [0, n).
+func checkElements(elements []uint, n uint) error {
+	for i, element := range elements {
+		if element >= n {
+			return fmt.Errorf("element %d out of range [0, %d)", element, n)
+		}
+		if i > 0 && elements[i-1] >= element {
+			return fmt.Errorf("elements not in strictly ascending order: %d, %d", elements[i-1], element)
+		}
+	}
+	return nil
+}
+
+// Rank returns the position (rank) in Cool-lex order of the combination of k=len(elements) out of n elements.
+// The elements must be in strictly ascending order. The first combination has rank 0.
+//
+// It is an error to pass no elements, out-of-range elements, or n and k such that C(n,k) does not fit in a
+// uint; see RankBig.
+func Rank(elements []uint, n uint) (uint, error) {
+	k := uint(len(elements))
+	if k == 0 {
+		return 0, fmt.Errorf("no elements")
+	}
+	if err := checkElements(elements, n); err != nil {
+		return 0, err
+	}
+	if _, err := numComb(n, k); err != nil {
+		return 0, err
+	}
+
+	// r: rank of the prefix among the strings with the same number of 0-bits (s) and 1-bits (t)
+	// b: number of such strings, i.e., C(s+t,t)
+	var r, b, s, t uint = 0, 1, 0, 0
+	for pos, next := uint(0), 0; pos < n; pos++ {
+		if next < len(elements) && elements[next] == pos {
+			next++
+			if s > 0 {
+				first := mulDiv(b, s, t+1) // C(s+t,t+1): strings (s-1 0-bits, t+1 1-bits) ending with a 0-bit
+				if r == 0 {
+					r = b - 1
+				} else {
+					r--
+				}
+				r += first
+				b += first
+			}
+			t++
+		} else {
+			b = mulDiv(b, s+t+1, s+1)
+			s++
+		}
+	}
+	return r, nil
+}
+
+// Unrank returns, in ascending order, the elements of the combination at position `rank` in Cool-lex order of
+// the combinations of k out of n elements.
+//
+// It is an error to pass k=0, n<k, `rank` out of range, or n and k such that C(n,k) does not fit in a uint;
+// see UnrankBig.
+func Unrank(rank, n, k uint) ([]uint, error) {
+	return unrank(rank, n, k, nil)
+}
+
+// unrank returns, in ascending order, the elements of the combination at position `r` in cool-lex order of
+// the combinations of k out of n elements. The elements are stored in `elements` if it has enough capacity.
+//
+// It is an error to pass k=0, n<k, `r` out of range, or n and k such that C(n,k) overflows.
+func unrank(r, n, k uint, elements []uint) ([]uint, error) {
+	if n < k {
+		return nil, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	if k == 0 {
+		return nil, fmt.Errorf("no combinations for k=0")
+	}
+	b, err := numComb(n, k)
+	if err != nil {
+		return nil, err
+	}
+	if r >= b {
+		return nil, fmt.Errorf("rank %d out of range [0, %d)", r, b)
+	}
+	elements = resize(elements, k)
+
+	s, t := n-k, k
+	for pos := n; s > 0 && t > 0; {
+		pos--
+		first := mulDiv(b, s, s+t) // C(s+t-1,t): strings ending with a 0-bit
+		if r < first {
+			b = first
+			s--
+			continue
+		}
+		r -= first
+		b -= first
+		if r++; r == b {
+			r = 0
+		}
+		t--
+		elements[t] = pos
+	}
+	// the remaining t elements, if any, occupy the lowest positions
+	for ; t > 0; t-- {
+		elements[t-1] = t - 1
+	}
+	return elements, nil
+}
+
+// RankBig returns the position (rank) in Cool-lex order of the combination of k=len(elements) out of n
+// elements. The elements must be in strictly ascending order. The first combination has rank 0.
+//
+// It is an error to pass no elements, or out-of-range elements.
+func RankBig(elements []uint, n uint) (*big.Int, error) {
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("no elements")
+	}
+	if err := checkElements(elements, n); err != nil {
+		return nil, err
+	}
+
+	// see Rank
+	r, b, first := new(big.Int), big.NewInt(1), new(big.Int)
+	var aux big.Int
+	var s, t uint
+	for pos, next := uint(0), 0; pos < n; pos++ {
+		if next < len(elements) && elements[next] == pos {
+			next++
+			if s > 0 {
+				first.Mul(b, aux.SetUint64(uint64(s))).Quo(first, aux.SetUint64(uint64(t+1)))
+				if len(r.Bits()) == 0 {
+					r.Sub(b, bigOne)
+				} else {
+					r.Sub(r, bigOne)
+				}
+				r.Add(r, first)
+				b.Add(b, first)
+			}
+			t++
+		} else {
+			b.Mul(b, aux.SetUint64(uint64(s+t+1))).Quo(b, aux.SetUint64(uint64(s+1)))
+			s++
+		}
+	}
+	return r, nil
+}
+
+// UnrankBig returns, in ascending order, the elements of the combination at position `rank` in Cool-lex order
+// of the combinations of k out of n elements.
+//
+// It is an error to pass k=0, n<k, or `rank` out of range.
+func UnrankBig(rank *big.Int, n, k uint) ([]uint, error) {
+	if n < k {
+		return nil, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	if k == 0 {
+		return nil, fmt.Errorf("no combinations for k=0")
+	}
+	b := binomial(n, k)
+	if rank.Sign() < 0 || rank.Cmp(b) >= 0 {
+		return nil, fmt.Errorf("rank %v out of range [0, %v)", rank, b)
+	}
+	elements := make([]uint, k)
+
+	// see unrank
+	r, first := new(big.Int).Set(rank), new(big.Int)
+	var aux big.Int
+	s, t := n-k, k
+	for pos := n; s > 0 && t > 0; {
+		pos--
+		first.Mul(b, aux.SetUint64(uint64(s))).Quo(first, aux.SetUint64(uint64(s+t)))
+		if r.Cmp(first) < 0 {
+			b, first = first, b
+			s--
+			continue
+		}
+		r.Sub(r, first)
+		b.Sub(b, first)
+		if r.Add(r, bigOne); r.Cmp(b) == 0 {
+			r.SetInt64(0)
+		}
+		t--
+		elements[t] = pos
+	}
+	for ; t > 0; t-- {
+		elements[t-1] = t - 1
+	}
+	return elements, nil
+}
+
+// binomial returns C(n,k)
+func binomial(n, k uint) *big.Int {
+	return new(big.Int).Binomial(int64(n), int64(k))
+}
+
+var bigOne = big.NewInt(1)
+
+// resize returns a slice of length `size`, reusing the storage of `s` if it has enough capacity.
+func resize(s []uint, size uint) []uint {
+	if uint(cap(s)) < size {
+		return make([]uint, size)
+	}
+	return s[:size]
+}
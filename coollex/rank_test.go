@@ -0,0 +1,133 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"math/big"
+	"math/bits"
+	"slices"
+	"testing"
+)
+
+func TestRankUnrank(t *testing.T) {
+	testCases := []struct{ n, k uint }{
+		{1, 1}, {5, 1}, {5, 5}, {6, 3}, {9, 4}, {15, 7}, {15, 8},
+	}
+	for _, tc := range testCases {
+		word, _ := NewComputerWord64(tc.n, tc.k)
+		expectRank := uint(0)
+		for combination := range word.Combinations() {
+			elements := slices.Collect(combination)
+			r, err := Rank(elements, tc.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r != expectRank {
+				t.Fatalf("rank: expected %d, got %d, for %v, n %d", expectRank, r, elements, tc.n)
+			}
+			actual, err := unrank(r, tc.n, tc.k, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(elements, actual) {
+				t.Fatalf("unrank: expected %v, got %v, for rank %d, n %d and k %d", elements, actual, r, tc.n, tc.k)
+			}
+			expectRank++
+		}
+	}
+}
+
+func TestRankErrors(t *testing.T) {
+	if _, err := Rank(nil, 3); err == nil {
+		t.Fatal("error is expected for no elements")
+	}
+	if _, err := Rank([]uint{1, 3}, 3); err == nil {
+		t.Fatal("error is expected for out-of-range elements")
+	}
+	if _, err := Rank([]uint{2, 1}, 3); err == nil {
+		t.Fatal("error is expected for unordered elements")
+	}
+	if _, err := unrank(3, 3, 2, nil); err == nil {
+		t.Fatal("error is expected for out-of-range rank")
+	}
+	if _, err := unrank(0, 200, 100, nil); err == nil {
+		t.Fatal("error is expected for overflowing C(n,k)")
+	}
+}
+
+func TestRankBig(t *testing.T) {
+	testCases := []struct{ n, k uint }{
+		{1, 1}, {9, 4}, {15, 7}, {40, 3},
+	}
+	for _, tc := range testCases {
+		word, _ := NewComputerWord64(tc.n, tc.k)
+		expectRank := int64(0)
+		for combination := range word.Combinations() {
+			elements := slices.Collect(combination)
+			r, err := RankBig(elements, tc.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Cmp(big.NewInt(expectRank)) != 0 {
+				t.Fatalf("rank: expected %d, got %v, for %v, n %d", expectRank, r, elements, tc.n)
+			}
+			actual, err := UnrankBig(r, tc.n, tc.k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(elements, actual) {
+				t.Fatalf("unrank: expected %v, got %v, for rank %v, n %d and k %d", elements, actual, r, tc.n, tc.k)
+			}
+			expectRank++
+		}
+	}
+	// beyond uint: round trip of the last rank, C(n,k)-1
+	n, k := uint(200), uint(100)
+	last := binomial(n, k)
+	last.Sub(last, bigOne)
+	elements, err := UnrankBig(last, n, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, _ := RankBig(elements, n); r.Cmp(last) != 0 {
+		t.Fatalf("rank: expected %v, got %v, for %v", last, r, elements)
+	}
+	if _, err := UnrankBig(binomial(n, k), n, k); err == nil {
+		t.Fatal("error is expected for out-of-range rank")
+	}
+}
+
+func TestRankLargestUint(t *testing.T) {
+	if bits.UintSize != 64 {
+		t.Skip("for 64-bit uint")
+	}
+	// C(67,33) fits in a uint, although intermediate products of simplemath.NumComb overflow
+	for _, tc := range []struct{ n, k uint }{{66, 33}, {67, 33}, {67, 34}, {64, 32}} {
+		count := binomial(tc.n, tc.k)
+		for _, r := range []*big.Int{new(big.Int), new(big.Int).Sub(count, bigOne), new(big.Int).Rsh(count, 1)} {
+			expect, _ := UnrankBig(r, tc.n, tc.k)
+			actual, err := Unrank(uint(r.Uint64()), tc.n, tc.k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(actual, expect) {
+				t.Fatalf("unrank: expected %v, got %v, for rank %v, n %d and k %d", expect, actual, r, tc.n, tc.k)
+			}
+			if rank, err := Rank(actual, tc.n); err != nil || uint64(rank) != r.Uint64() {
+				t.Fatalf("rank: expected %v, got %d (%v), for %v", r, rank, err, actual)
+			}
+		}
+	}
+	if _, err := Unrank(0, 68, 34); err == nil {
+		t.Fatal("error is expected for C(68,34) greater than 2^64-1")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package reference implements the Cool-lex order straight from its recursive definition, for verifying
+the algorithms in the coollex package. It favours simplicity over performance.
+
+For binary strings with `s` 0-bits and `t` 1-bits, the 1-bits denoting selected elements and position 0
+written first, the Cool-lex order is:
+
+	C(s,t) = C(s-1,t)·0, rotate(C(s,t-1))·1, for s>0 and t>0
+	C(s,t) = 1^t 0^s, otherwise
+
+where `rotate` moves the first string of a list, that is, 1^t 0^s, to its end.
+
+Refer to "The Coolest Way to Generate Combinations" paper by Frank Ruskey and Aaron Williams,
+http://webhome.cs.uvic.ca/~ruskey/Publications/Coollex/CoolComb.html.
+*/
+package reference
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// generate writes into b[:s+t] the strings of C(s,t) in order, calling yield after each one.
+// It reports whether yield returned true for all strings.
+func generate(b []bool, s, t uint, yield func() bool) bool {
+	n := s + t
+	if s == 0 || t == 0 {
+		for i := range n {
+			b[i] = i < t
+		}
+		return yield()
+	}
+
+	b[n-1] = false
+	if !generate(b, s-1, t, yield) {
+		return false
+	}
+
+	b[n-1] = true
+	first := true
+	rotated := func() bool {
+		if first {
+			first = false // moved to the end
+			return true
+		}
+		return yield()
+	}
+	if !generate(b, s, t-1, rotated) {
+		return false
+	}
+	for i := range n - 1 {
+		b[i] = i < t-1
+	}
+	return yield()
+}
+
+// Combinations returns an iterator over the combinations of k out of n elements, in Cool-lex order.
+// A combination is represented by its elements, in ascending order.
+//
+// Consistent with the algorithms in the coollex package, no combinations are yielded for k=0 or n<k.
+//
+// Note: the slice is reused between iterations and should only be used for reading.
+func Combinations(n, k uint) iter.Seq[[]uint] {
+	return func(yield func([]uint) bool) {
+		if k == 0 || n < k {
+			return
+		}
+		b := make([]bool, n)
+		elements := make([]uint, 0, k)
+		generate(b, n-k, k, func() bool {
+			elements = elements[:0]
+			for i, selected := range b {
+				if selected {
+					elements = append(elements, uint(i))
+				}
+			}
+			return yield(elements)
+		})
+	}
+}
+
+// Compare verifies that `combinations` yields exactly the combinations of k out of n elements, in Cool-lex
+// order, with the elements of each combination in ascending order.
+//
+// It returns an error describing the first deviation, if any.
+func Compare(n, k uint, combinations iter.Seq[iter.Seq[uint]]) error {
+	next, stop := iter.Pull(Combinations(n, k))
+	defer stop()
+
+	position := 0
+	actual := make([]uint, 0, k)
+	for combination := range combinations {
+		actual = slices.AppendSeq(actual[:0], combination)
+		expect, ok := next()
+		if !ok {
+			return fmt.Errorf("combination %d: unexpected %v, after the last combination, for n %d and k %d", position, actual, n, k)
+		}
+		if !slices.Equal(expect, actual) {
+			return fmt.Errorf("combination %d: expected %v, got %v, for n %d and k %d", position, expect, actual, n, k)
+		}
+		position++
+	}
+	if expect, ok := next(); ok {
+		return fmt.Errorf("combination %d: expected %v, got none, for n %d and k %d", position, expect, n, k)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package reference
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/dastoikov/cool-lex-go/v2/simplemath"
+)
+
+// toStrings returns the combinations as binary strings, position 0 written first.
+func toStrings(n, k uint) []string {
+	var result []string
+	for elements := range Combinations(n, k) {
+		b := []byte(fmt.Sprintf("%0*d", n, 0))
+		for _, element := range elements {
+			b[element] = '1'
+		}
+		result = append(result, string(b))
+	}
+	return result
+}
+
+func TestCombinations(t *testing.T) {
+	testCases := []struct {
+		n, k   uint
+		expect []string
+	}{
+		{4, 2, []string{"1100", "0110", "1010", "0101", "0011", "1001"}},
+		{5, 3, []string{"11100", "01110", "10110", "11010", "01101", "10101", "01011", "00111", "10011", "11001"}},
+		{3, 1, []string{"100", "010", "001"}},
+		{3, 3, []string{"111"}},
+		{3, 0, nil},
+		{2, 3, nil},
+	}
+	for _, tc := range testCases {
+		if actual := toStrings(tc.n, tc.k); !slices.Equal(tc.expect, actual) {
+			t.Fatalf("expected %v, got %v, for n %d and k %d", tc.expect, actual, tc.n, tc.k)
+		}
+	}
+	for n := uint(1); n < 12; n++ {
+		for k := uint(1); k <= n; k++ {
+			expect, _ := simplemath.NumComb(n, k)
+			if actual := uint(len(toStrings(n, k))); expect != actual {
+				t.Fatalf("number of combinations: expected %d, got %d, for n %d and k %d", expect, actual, n, k)
+			}
+		}
+	}
+}
+
+// fromSlices returns an iterator over the specified combinations.
+func fromSlices(combinations ...[]uint) iter.Seq[iter.Seq[uint]] {
+	return func(yield func(iter.Seq[uint]) bool) {
+		for _, combination := range combinations {
+			if !yield(slices.Values(combination)) {
+				return
+			}
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		combinations iter.Seq[iter.Seq[uint]]
+		valid        bool
+	}{
+		{fromSlices([]uint{0, 1}, []uint{1, 2}, []uint{0, 2}), true},
+		{fromSlices([]uint{0, 1}, []uint{0, 2}, []uint{1, 2}), false},
+		{fromSlices([]uint{0, 1}, []uint{1, 2}), false},
+		{fromSlices([]uint{0, 1}, []uint{1, 2}, []uint{0, 2}, []uint{0, 1}), false},
+		{fromSlices([]uint{0, 1}, []uint{2, 1}, []uint{0, 2}), false},
+	}
+	for i, tc := range testCases {
+		if err := Compare(3, 2, tc.combinations); (err == nil) != tc.valid {
+			t.Fatalf("test case %d: expected valid %t, got %v", i, tc.valid, err)
+		}
+	}
+	if err := Compare(3, 0, fromSlices()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// Sampling draws a uniformly distributed rank and unranks it, so that a sampled combination can be related
+// back to its position in Cool-lex order, see Rank and RankBig. Samples are reproducible for a seeded source,
+// for example `rand.NewPCG(seed1, seed2)`.
+
+// randomBig returns a uniformly distributed value in [0, limit), by rejection sampling.
+// Precondition: `limit>0`.
+func randomBig(src rand.Source, limit *big.Int) *big.Int {
+	bitLen := uint(limit.BitLen())
+	words := make([]big.Word, (bitLen+bits.UintSize-1)/bits.UintSize)
+	r := new(big.Int)
+	for {
+		for i := range words {
+			words[i] = big.Word(src.Uint64())
+		}
+		if excess := uint(len(words))*bits.UintSize - bitLen; excess > 0 {
+			words[len(words)-1] >>= excess
+		}
+		if r.SetBits(words).Cmp(limit) < 0 {
+			return r
+		}
+	}
+}
+
+// RandomRank returns a uniformly distributed rank in [0, C(n,k)), drawn from `src`.
+//
+// It is an error to pass arguments such that n < k or k = 0.
+func RandomRank(src rand.Source, n, k uint) (*big.Int, error) {
+	if n < k {
+		return nil, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	if k == 0 {
+		return nil, fmt.Errorf("no combinations for k=0")
+	}
+	return randomBig(src, binomial(n, k)), nil
+}
+
+// RandomElements returns a uniformly distributed combination of k out of n elements, drawn from `src`.
+// The elements are in ascending order.
+//
+// It is an error to pass arguments such that n < k or k = 0.
+func RandomElements(src rand.Source, n, k uint) ([]uint, error) {
+	rank, err := RandomRank(src, n, k)
+	if err != nil {
+		return nil, err
+	}
+	return UnrankBig(rank, n, k)
+}
+
+// RandomWord64 returns a uniformly distributed combination of k out of n elements, drawn from `src`,
+// represented as in ComputerWord64.Words.
+//
+// It is an error to pass arguments such that n < k, n >= 64, or k = 0.
+func RandomWord64(src rand.Source, n, k uint) (int64, error) {
+	if n >= 64 {
+		return 0, fmt.Errorf("n (%d) greater than 63, consider using RandomWordBig", n)
+	}
+	elements, err := RandomElements(src, n, k)
+	if err != nil {
+		return 0, err
+	}
+	return toWord64(elements), nil
+}
+
+// RandomWordBig returns a uniformly distributed combination of k out of n elements, drawn from `src`,
+// represented as in ComputerWordBig.Words.
+//
+// It is an error to pass arguments such that n < k or k = 0.
+func RandomWordBig(src rand.Source, n, k uint) (*big.Int, error) {
+	elements, err := RandomElements(src, n, k)
+	if err != nil {
+		return nil, err
+	}
+	word := new(big.Int)
+	for _, element := range elements {
+		word.SetBit(word, int(element), 1)
+	}
+	return word, nil
+}
+
+// RandomComputerWord64 returns a ComputerWord64 generator positioned at a uniformly distributed rank, drawn
+// from `src`, and the rank. See NewComputerWord64At.
+//
+// It is an error to pass arguments such that n < k, n >= 64, or k = 0.
+func RandomComputerWord64(src rand.Source, n, k uint) (ComputerWord64, uint, error) {
+	if n >= 64 {
+		return ComputerWord64{}, 0, fmt.Errorf("n (%d) greater than 63, consider using RandomComputerWordBig", n)
+	}
+	rank, err := RandomRank(src, n, k)
+	if err != nil {
+		return ComputerWord64{}, 0, err
+	}
+	word, err := NewComputerWord64At(n, k, uint(rank.Uint64()))
+	return word, uint(rank.Uint64()), err
+}
+
+// RandomComputerWordBig returns a ComputerWordBig generator positioned at a uniformly distributed rank, drawn
+// from `src`, and the rank. See NewComputerWordBigAt.
+//
+// It is an error to pass arguments such that n < k or k = 0.
+func RandomComputerWordBig(src rand.Source, n, k uint) (ComputerWordBig, *big.Int, error) {
+	rank, err := RandomRank(src, n, k)
+	if err != nil {
+		return ComputerWordBig{}, nil, err
+	}
+	word, err := NewComputerWordBigAt(n, k, rank)
+	return word, rank, err
+}
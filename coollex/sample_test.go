@@ -0,0 +1,147 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"math/big"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestRandomRankDistribution(t *testing.T) {
+	const n, k, samples = 5, 2, 10000 // C(5,2)=10 ranks
+	src := rand.NewPCG(1, 2)
+	hits := make([]int, 10)
+	for range samples {
+		rank, err := RandomRank(src, n, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hits[rank.Uint64()]++
+	}
+	for rank, hit := range hits {
+		// expected 1000 per rank; the bounds are well beyond 5 standard deviations (~95)
+		if hit < 500 || hit > 1500 {
+			t.Fatalf("rank %d sampled %d times out of %d", rank, hit, samples)
+		}
+	}
+}
+
+func TestRandomReproducible(t *testing.T) {
+	a, b := rand.NewPCG(7, 11), rand.NewPCG(7, 11)
+	for range 100 {
+		expect, err := RandomWordBig(a, 200, 37)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, _ := RandomWordBig(b, 200, 37)
+		if expect.Cmp(actual) != 0 {
+			t.Fatalf("expected %v, got %v, for the same seed", expect, actual)
+		}
+	}
+}
+
+func TestRandomWords(t *testing.T) {
+	src := rand.NewPCG(3, 5)
+	for range 100 {
+		word, err := RandomWord64(src, 40, 9)
+		if err != nil {
+			t.Fatal(err)
+		}
+		elements := slices.Collect(elements64(word))
+		if len(elements) != 9 || elements[len(elements)-1] >= 40 {
+			t.Fatalf("unexpected word %b for n 40 and k 9", word)
+		}
+	}
+	for range 100 {
+		word, err := RandomWordBig(src, 300, 120)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if word.BitLen() > 300 {
+			t.Fatalf("unexpected word %v for n 300 and k 120", word)
+		}
+		count := 0
+		for _, w := range word.Bits() {
+			count += popCount(w)
+		}
+		if count != 120 {
+			t.Fatalf("number of bits set: expected 120, got %d", count)
+		}
+	}
+}
+
+func popCount(w big.Word) int {
+	count := 0
+	for ; w != 0; w &= w - 1 {
+		count++
+	}
+	return count
+}
+
+func TestRandomComputerWord(t *testing.T) {
+	src := rand.NewPCG(13, 17)
+	for range 20 {
+		word, rank, err := RandomComputerWord64(src, 12, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		i := rank
+		for w := range word.Words() {
+			expect, _ := Unrank(i, 12, 5)
+			if toWord64(expect) != w {
+				t.Fatalf("combination %d: expected %v, got %b", i, expect, w)
+			}
+			i++
+		}
+		if i != 792 { // C(12,5)
+			t.Fatalf("number of combinations: expected 792, got %d, starting at rank %d", i, rank)
+		}
+	}
+	for range 20 {
+		word, rank, err := RandomComputerWordBig(src, 12, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect, _ := Unrank(uint(rank.Uint64()), 12, 5)
+		for combination := range word.Combinations() {
+			if actual := slices.Collect(combination); !slices.Equal(expect, actual) {
+				t.Fatalf("expected %v, got %v, at rank %v", expect, actual, rank)
+			}
+			break
+		}
+		count := uint64(0)
+		for range word.Combinations() {
+			count++
+		}
+		if count != 792-rank.Uint64() {
+			t.Fatalf("number of combinations: expected %d, got %d, starting at rank %v", 792-rank.Uint64(), count, rank)
+		}
+	}
+}
+
+func TestRandomErrors(t *testing.T) {
+	src := rand.NewPCG(0, 0)
+	if _, err := RandomRank(src, 3, 0); err == nil {
+		t.Fatal("error is expected for k=0")
+	}
+	if _, err := RandomElements(src, 2, 3); err == nil {
+		t.Fatal("error is expected for n<k")
+	}
+	if _, err := RandomWord64(src, 64, 3); err == nil {
+		t.Fatal("error is expected for n>=64")
+	}
+	if _, _, err := RandomComputerWord64(src, 64, 3); err == nil {
+		t.Fatal("error is expected for n>=64")
+	}
+}
@@ -0,0 +1,479 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"fmt"
+	"iter"
+	"math/big"
+	"math/bits"
+	"slices"
+)
+
+// MaxIndexed is the greatest number of combinations, C(n,k), of a CombinationSet or a CombinationMap. Both are
+// dense, allocating for every combination: a set C(n,k) bits, and a map also C(n,k) values.
+const MaxIndexed = 1 << 30
+
+// rankIndex maps the combinations of k out of n elements to their ranks in Cool-lex order
+type rankIndex struct {
+	n, k  uint
+	count uint // C(n,k)
+}
+
+// newRankIndex returns the index of the combinations of k out of n elements.
+//
+// It is an error to pass arguments such that n < k, k = 0, or C(n,k) > MaxIndexed.
+func newRankIndex(n, k uint) (rankIndex, error) {
+	if n < k {
+		return rankIndex{}, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	if k == 0 {
+		return rankIndex{}, fmt.Errorf("no combinations for k=0")
+	}
+	count, err := numComb(n, k)
+	if err == nil && count > MaxIndexed {
+		err = fmt.Errorf("C(%d,%d)=%d more than %d combinations, see MaxIndexed", n, k, count, MaxIndexed)
+	}
+	if err != nil {
+		return rankIndex{}, err
+	}
+	return rankIndex{n: n, k: k, count: count}, nil
+}
+
+// rank returns the rank of the combination of the elements, in strictly ascending order
+func (index *rankIndex) rank(elements []uint) (uint, error) {
+	if uint(len(elements)) != index.k {
+		return 0, fmt.Errorf("%d elements, expected k=%d", len(elements), index.k)
+	}
+	return Rank(elements, index.n)
+}
+
+// rankWord64 returns the rank of the combination represented as in `ComputerWord64.Words()`
+func (index *rankIndex) rankWord64(word int64) (uint, error) {
+	var storage [64]uint
+	elements := storage[:0]
+	for r := uint64(word); r != 0; r &= r - 1 {
+		elements = append(elements, uint(bits.TrailingZeros64(r)))
+	}
+	return index.rank(elements)
+}
+
+// rankWordBig returns the rank of the combination represented as in `ComputerWordBig.Words()`
+func (index *rankIndex) rankWordBig(word *big.Int) (uint, error) {
+	if word.Sign() < 0 {
+		return 0, fmt.Errorf("negative word %v", word)
+	}
+	elements := make([]uint, 0, index.k)
+	for i, v := range word.Bits() {
+		for r := uint(v); r != 0; r &= r - 1 {
+			elements = append(elements, uint(i*bits.UintSize+bits.TrailingZeros(r)))
+		}
+	}
+	return index.rank(elements)
+}
+
+// CombinationSet is a set of combinations of k out of n elements. It is a bitmap of C(n,k) bits, the bit i
+// set for the combination at position i in Cool-lex order.
+//
+// A combination is specified by its elements, in strictly ascending order, or by its word, as in
+// `ComputerWord64.Words()` and `ComputerWordBig.Words()`.
+type CombinationSet struct {
+	index rankIndex
+	bits  []uint64
+}
+
+// NewCombinationSet returns an empty set of combinations of k out of n elements.
+//
+// It is an error to pass arguments such that n < k, k = 0, or C(n,k) > MaxIndexed.
+func NewCombinationSet(n, k uint) (CombinationSet, error) {
+	index, err := newRankIndex(n, k)
+	if err != nil {
+		return CombinationSet{}, err
+	}
+	return CombinationSet{index: index, bits: make([]uint64, (index.count+63)/64)}, nil
+}
+
+// N returns the number of elements to combine.
+func (set *CombinationSet) N() uint {
+	return set.index.n
+}
+
+// K returns the number of elements in each combination.
+func (set *CombinationSet) K() uint {
+	return set.index.k
+}
+
+func (set *CombinationSet) add(rank uint) {
+	set.bits[rank/64] |= 1 << (rank % 64)
+}
+
+func (set *CombinationSet) remove(rank uint) {
+	set.bits[rank/64] &^= 1 << (rank % 64)
+}
+
+func (set *CombinationSet) contains(rank uint) bool {
+	return set.bits[rank/64]&(1<<(rank%64)) != 0
+}
+
+// Add adds the combination of the elements.
+//
+// It is an error to pass elements that are not a combination of k out of n elements, in strictly ascending
+// order.
+func (set *CombinationSet) Add(elements []uint) error {
+	rank, err := set.index.rank(elements)
+	if err != nil {
+		return err
+	}
+	set.add(rank)
+	return nil
+}
+
+// AddWord64 adds the combination represented as in `ComputerWord64.Words()`.
+//
+// It is an error to pass a word that is not a combination of k out of n elements.
+func (set *CombinationSet) AddWord64(word int64) error {
+	rank, err := set.index.rankWord64(word)
+	if err != nil {
+		return err
+	}
+	set.add(rank)
+	return nil
+}
+
+// AddWordBig adds the combination represented as in `ComputerWordBig.Words()`.
+//
+// It is an error to pass a word that is not a combination of k out of n elements.
+func (set *CombinationSet) AddWordBig(word *big.Int) error {
+	rank, err := set.index.rankWordBig(word)
+	if err != nil {
+		return err
+	}
+	set.add(rank)
+	return nil
+}
+
+// Remove removes the combination of the elements, if present.
+//
+// It is an error to pass elements that are not a combination of k out of n elements, in strictly ascending
+// order.
+func (set *CombinationSet) Remove(elements []uint) error {
+	rank, err := set.index.rank(elements)
+	if err != nil {
+		return err
+	}
+	set.remove(rank)
+	return nil
+}
+
+// RemoveWord64 removes the combination represented as in `ComputerWord64.Words()`, if present.
+//
+// It is an error to pass a word that is not a combination of k out of n elements.
+func (set *CombinationSet) RemoveWord64(word int64) error {
+	rank, err := set.index.rankWord64(word)
+	if err != nil {
+		return err
+	}
+	set.remove(rank)
+	return nil
+}
+
+// RemoveWordBig removes the combination represented as in `ComputerWordBig.Words()`, if present.
+//
+// It is an error to pass a word that is not a combination of k out of n elements.
+func (set *CombinationSet) RemoveWordBig(word *big.Int) error {
+	rank, err := set.index.rankWordBig(word)
+	if err != nil {
+		return err
+	}
+	set.remove(rank)
+	return nil
+}
+
+// Contains reports whether the set contains the combination of the elements; false for elements that are not
+// a combination of k out of n elements, in strictly ascending order.
+func (set *CombinationSet) Contains(elements []uint) bool {
+	rank, err := set.index.rank(elements)
+	return err == nil && set.contains(rank)
+}
+
+// ContainsWord64 reports whether the set contains the combination represented as in
+// `ComputerWord64.Words()`; false for a word that is not a combination of k out of n elements.
+func (set *CombinationSet) ContainsWord64(word int64) bool {
+	rank, err := set.index.rankWord64(word)
+	return err == nil && set.contains(rank)
+}
+
+// ContainsWordBig reports whether the set contains the combination represented as in
+// `ComputerWordBig.Words()`; false for a word that is not a combination of k out of n elements.
+func (set *CombinationSet) ContainsWordBig(word *big.Int) bool {
+	rank, err := set.index.rankWordBig(word)
+	return err == nil && set.contains(rank)
+}
+
+// Count returns the number of combinations in the set.
+func (set *CombinationSet) Count() uint {
+	count := 0
+	for _, w := range set.bits {
+		count += bits.OnesCount64(w)
+	}
+	return uint(count)
+}
+
+// checkCompatible reports an error unless the sets are of combinations of the same k out of the same n elements
+func (set *CombinationSet) checkCompatible(other *CombinationSet) error {
+	if set.index.n != other.index.n || set.index.k != other.index.k {
+		return fmt.Errorf("combinations of %d out of %d elements, expected %d out of %d",
+			other.index.k, other.index.n, set.index.k, set.index.n)
+	}
+	return nil
+}
+
+// Union adds the combinations of `other` to the set.
+//
+// It is an error to pass a set of combinations of a different k, or out of a different n.
+func (set *CombinationSet) Union(other *CombinationSet) error {
+	if err := set.checkCompatible(other); err != nil {
+		return err
+	}
+	for i, w := range other.bits {
+		set.bits[i] |= w
+	}
+	return nil
+}
+
+// Intersect removes the combinations that are not in `other` from the set.
+//
+// It is an error to pass a set of combinations of a different k, or out of a different n.
+func (set *CombinationSet) Intersect(other *CombinationSet) error {
+	if err := set.checkCompatible(other); err != nil {
+		return err
+	}
+	for i, w := range other.bits {
+		set.bits[i] &= w
+	}
+	return nil
+}
+
+// Clone returns a copy of the set.
+func (set *CombinationSet) Clone() CombinationSet {
+	return CombinationSet{index: set.index, bits: slices.Clone(set.bits)}
+}
+
+// Ranks returns an iterator over the ranks of the combinations in the set, in ascending order.
+func (set *CombinationSet) Ranks() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for i, w := range set.bits {
+			for ; w != 0; w &= w - 1 {
+				if !yield(uint(i*64 + bits.TrailingZeros64(w))) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Combinations returns an iterator over the combinations in the set, in Cool-lex order. Unlike the generators,
+// every call of the iterator starts with the first combination.
+func (set *CombinationSet) Combinations() Combinations {
+	return func(yield func(Elements) bool) {
+		var elements []uint
+		for rank := range set.Ranks() {
+			elements, _ = unrank(rank, set.index.n, set.index.k, elements) // no error for a valid rank
+			if !yield(slices.Values(elements)) {
+				return
+			}
+		}
+	}
+}
+
+// CombinationMap maps combinations of k out of n elements to values of type V. The values are stored in a
+// slice of C(n,k) values, the value at index i for the combination at position i in Cool-lex order.
+//
+// A combination is specified by its elements, in strictly ascending order, or by its word, as in
+// `ComputerWord64.Words()` and `ComputerWordBig.Words()`.
+type CombinationMap[V any] struct {
+	keys   CombinationSet
+	values []V
+}
+
+// NewCombinationMap returns an empty map of combinations of k out of n elements.
+//
+// It is an error to pass arguments such that n < k, k = 0, or C(n,k) > MaxIndexed.
+func NewCombinationMap[V any](n, k uint) (CombinationMap[V], error) {
+	keys, err := NewCombinationSet(n, k)
+	if err != nil {
+		return CombinationMap[V]{}, err
+	}
+	return CombinationMap[V]{keys: keys, values: make([]V, keys.index.count)}, nil
+}
+
+// N returns the number of elements to combine.
+func (m *CombinationMap[V]) N() uint {
+	return m.keys.index.n
+}
+
+// K returns the number of elements in each combination.
+func (m *CombinationMap[V]) K() uint {
+	return m.keys.index.k
+}
+
+func (m *CombinationMap[V]) set(rank uint, value V) {
+	m.keys.add(rank)
+	m.values[rank] = value
+}
+
+func (m *CombinationMap[V]) get(rank uint) (V, bool) {
+	if !m.keys.contains(rank) {
+		var zero V
+		return zero, false
+	}
+	return m.values[rank], true
+}
+
+func (m *CombinationMap[V]) delete(rank uint) {
+	m.keys.remove(rank)
+	var zero V
+	m.values[rank] = zero
+}
+
+// Set maps the combination of the elements to the value.
+//
+// It is an error to pass elements that are not a combination of k out of n elements, in strictly ascending
+// order.
+func (m *CombinationMap[V]) Set(elements []uint, value V) error {
+	rank, err := m.keys.index.rank(elements)
+	if err != nil {
+		return err
+	}
+	m.set(rank, value)
+	return nil
+}
+
+// SetWord64 maps the combination represented as in `ComputerWord64.Words()` to the value.
+//
+// It is an error to pass a word that is not a combination of k out of n elements.
+func (m *CombinationMap[V]) SetWord64(word int64, value V) error {
+	rank, err := m.keys.index.rankWord64(word)
+	if err != nil {
+		return err
+	}
+	m.set(rank, value)
+	return nil
+}
+
+// SetWordBig maps the combination represented as in `ComputerWordBig.Words()` to the value.
+//
+// It is an error to pass a word that is not a combination of k out of n elements.
+func (m *CombinationMap[V]) SetWordBig(word *big.Int, value V) error {
+	rank, err := m.keys.index.rankWordBig(word)
+	if err != nil {
+		return err
+	}
+	m.set(rank, value)
+	return nil
+}
+
+// Get returns the value of the combination of the elements, and whether it is present; not present for
+// elements that are not a combination of k out of n elements, in strictly ascending order.
+func (m *CombinationMap[V]) Get(elements []uint) (V, bool) {
+	rank, err := m.keys.index.rank(elements)
+	if err != nil {
+		var zero V
+		return zero, false
+	}
+	return m.get(rank)
+}
+
+// GetWord64 returns the value of the combination represented as in `ComputerWord64.Words()`, and whether it is
+// present; not present for a word that is not a combination of k out of n elements.
+func (m *CombinationMap[V]) GetWord64(word int64) (V, bool) {
+	rank, err := m.keys.index.rankWord64(word)
+	if err != nil {
+		var zero V
+		return zero, false
+	}
+	return m.get(rank)
+}
+
+// GetWordBig returns the value of the combination represented as in `ComputerWordBig.Words()`, and whether it
+// is present; not present for a word that is not a combination of k out of n elements.
+func (m *CombinationMap[V]) GetWordBig(word *big.Int) (V, bool) {
+	rank, err := m.keys.index.rankWordBig(word)
+	if err != nil {
+		var zero V
+		return zero, false
+	}
+	return m.get(rank)
+}
+
+// Delete removes the combination of the elements, if present.
+//
+// It is an error to pass elements that are not a combination of k out of n elements, in strictly ascending
+// order.
+func (m *CombinationMap[V]) Delete(elements []uint) error {
+	rank, err := m.keys.index.rank(elements)
+	if err != nil {
+		return err
+	}
+	m.delete(rank)
+	return nil
+}
+
+// DeleteWord64 removes the combination represented as in `ComputerWord64.Words()`, if present.
+//
+// It is an error to pass a word that is not a combination of k out of n elements.
+func (m *CombinationMap[V]) DeleteWord64(word int64) error {
+	rank, err := m.keys.index.rankWord64(word)
+	if err != nil {
+		return err
+	}
+	m.delete(rank)
+	return nil
+}
+
+// DeleteWordBig removes the combination represented as in `ComputerWordBig.Words()`, if present.
+//
+// It is an error to pass a word that is not a combination of k out of n elements.
+func (m *CombinationMap[V]) DeleteWordBig(word *big.Int) error {
+	rank, err := m.keys.index.rankWordBig(word)
+	if err != nil {
+		return err
+	}
+	m.delete(rank)
+	return nil
+}
+
+// Count returns the number of combinations in the map.
+func (m *CombinationMap[V]) Count() uint {
+	return m.keys.Count()
+}
+
+// Keys returns the set of the combinations in the map. The set is shared with the map, and should only be
+// used for reading.
+func (m *CombinationMap[V]) Keys() *CombinationSet {
+	return &m.keys
+}
+
+// All returns an iterator over the combinations in the map, in Cool-lex order, and their values. Unlike the
+// generators, every call of the iterator starts with the first combination.
+func (m *CombinationMap[V]) All() iter.Seq2[Elements, V] {
+	return func(yield func(Elements, V) bool) {
+		var elements []uint
+		for rank := range m.keys.Ranks() {
+			elements, _ = unrank(rank, m.keys.index.n, m.keys.index.k, elements) // no error for a valid rank
+			if !yield(slices.Values(elements), m.values[rank]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestCombinationSet(t *testing.T) {
+	const n, k = 9, 4
+	set, err := NewCombinationSet(n, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every third combination, by elements, 64-bit words and big words alternately
+	word, _ := NewComputerWord64(n, k)
+	var expect [][]uint
+	i := 0
+	for w := range word.Words() {
+		elements := slices.Collect(elements64(w))
+		switch i % 9 {
+		case 0:
+			err = set.Add(elements)
+		case 3:
+			err = set.AddWord64(w)
+		case 6:
+			err = set.AddWordBig(big.NewInt(w))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i%3 == 0 {
+			expect = append(expect, elements)
+		}
+		if set.Contains(elements) != (i%3 == 0) || set.ContainsWord64(w) != (i%3 == 0) ||
+			set.ContainsWordBig(big.NewInt(w)) != (i%3 == 0) {
+			t.Fatalf("unexpected membership of %v", elements)
+		}
+		i++
+	}
+	if set.Count() != uint(len(expect)) {
+		t.Fatalf("expected %d combinations, got %d", len(expect), set.Count())
+	}
+	var actual [][]uint
+	for combination := range set.Combinations() {
+		actual = append(actual, slices.Collect(combination))
+	}
+	if !slices.EqualFunc(actual, expect, slices.Equal) {
+		t.Fatalf("expected %v, got %v", expect, actual)
+	}
+
+	if err := set.Remove(expect[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.RemoveWord64(toWord64(expect[1])); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.RemoveWordBig(big.NewInt(toWord64(expect[2]))); err != nil {
+		t.Fatal(err)
+	}
+	if set.Contains(expect[0]) || set.Contains(expect[1]) || set.Contains(expect[2]) ||
+		set.Count() != uint(len(expect)-3) {
+		t.Fatal("expected the combinations to be removed")
+	}
+	if set.Contains([]uint{0, 1, 2}) || set.ContainsWord64(-1) || set.ContainsWordBig(big.NewInt(-1)) {
+		t.Fatal("expected no membership for an invalid combination")
+	}
+}
+
+func TestCombinationSetOperations(t *testing.T) {
+	a, _ := NewCombinationSet(6, 2)
+	b, _ := NewCombinationSet(6, 2)
+	a.Add([]uint{0, 1})
+	a.Add([]uint{2, 5})
+	b.Add([]uint{2, 5})
+	b.Add([]uint{3, 4})
+
+	union := a.Clone()
+	if err := union.Union(&b); err != nil {
+		t.Fatal(err)
+	}
+	if union.Count() != 3 || a.Count() != 2 {
+		t.Fatalf("expected 3 combinations in the union, got %d; %d in the original", union.Count(), a.Count())
+	}
+	if err := a.Intersect(&b); err != nil {
+		t.Fatal(err)
+	}
+	if a.Count() != 1 || !a.Contains([]uint{2, 5}) {
+		t.Fatalf("expected only [2 5] in the intersection, got %d combinations", a.Count())
+	}
+
+	other, _ := NewCombinationSet(7, 2)
+	if a.Union(&other) == nil || a.Intersect(&other) == nil {
+		t.Fatal("error is expected for a different n")
+	}
+}
+
+func TestCombinationSetErrors(t *testing.T) {
+	if _, err := NewCombinationSet(3, 4); err == nil {
+		t.Fatal("error is expected for n<k")
+	}
+	if _, err := NewCombinationSet(3, 0); err == nil {
+		t.Fatal("error is expected for k=0")
+	}
+	if _, err := NewCombinationSet(200, 100); err == nil {
+		t.Fatal("error is expected for C(n,k) overflow")
+	}
+	// C(n,k) fits in a uint, yet is more than MaxIndexed
+	for _, tc := range []struct{ n, k uint }{{64, 32}, {33, 16}} {
+		if _, err := NewCombinationSet(tc.n, tc.k); err == nil {
+			t.Fatalf("error is expected for C(%d,%d) more than MaxIndexed", tc.n, tc.k)
+		}
+		if _, err := NewCombinationMap[int](tc.n, tc.k); err == nil {
+			t.Fatalf("error is expected for C(%d,%d) more than MaxIndexed", tc.n, tc.k)
+		}
+	}
+	set, _ := NewCombinationSet(5, 2)
+	if set.Add([]uint{1}) == nil || set.Add([]uint{1, 5}) == nil || set.Add([]uint{3, 1}) == nil {
+		t.Fatal("error is expected for an invalid combination")
+	}
+	if set.AddWord64(0b111) == nil || set.AddWordBig(big.NewInt(-3)) == nil || set.RemoveWord64(1<<5|1) == nil {
+		t.Fatal("error is expected for an invalid word")
+	}
+}
+
+func TestCombinationMap(t *testing.T) {
+	const n, k = 70, 2
+	m, err := NewCombinationMap[string](n, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Set([]uint{3, 69}, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetWord64(0b11, "b"); err != nil {
+		t.Fatal(err)
+	}
+	wide := new(big.Int).SetBit(new(big.Int).SetBit(new(big.Int), 68, 1), 69, 1)
+	if err := m.SetWordBig(wide, "c"); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := m.GetWordBig(new(big.Int).SetBit(big.NewInt(8), 69, 1)); !ok || v != "a" {
+		t.Fatalf("expected a, got %q, %v", v, ok)
+	}
+	if v, ok := m.Get([]uint{0, 1}); !ok || v != "b" {
+		t.Fatalf("expected b, got %q, %v", v, ok)
+	}
+	if _, ok := m.GetWord64(0b101); ok {
+		t.Fatal("expected no value")
+	}
+	if m.Count() != 3 || m.Keys().Count() != 3 {
+		t.Fatalf("expected 3 combinations, got %d", m.Count())
+	}
+
+	// in Cool-lex order: [0 1] first; [3 69] precedes [68 69]
+	var values []string
+	var combinations [][]uint
+	for combination, v := range m.All() {
+		combinations = append(combinations, slices.Collect(combination))
+		values = append(values, v)
+	}
+	if !slices.Equal(values, []string{"b", "a", "c"}) ||
+		!slices.EqualFunc(combinations, [][]uint{{0, 1}, {3, 69}, {68, 69}}, slices.Equal) {
+		t.Fatalf("unexpected iteration %v: %v", combinations, values)
+	}
+
+	if err := m.Delete([]uint{3, 69}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.DeleteWord64(0b11); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.DeleteWordBig(wide); err != nil {
+		t.Fatal(err)
+	}
+	if m.Count() != 0 {
+		t.Fatalf("expected no combinations, got %d", m.Count())
+	}
+	if m.Set([]uint{0, 70}, "x") == nil {
+		t.Fatal("error is expected for an out-of-range element")
+	}
+}
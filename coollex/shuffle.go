@@ -0,0 +1,243 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"math/big"
+	"math/bits"
+	"slices"
+)
+
+// feistelRounds is the number of rounds of the Feistel network. The round function is not cryptographically
+// keyed, the network is meant to decorrelate the order of the visits, not to hide it.
+const feistelRounds = 8
+
+// feistel is a balanced Feistel network, permuting the values of 2*half bits.
+type feistel struct {
+	half uint
+	keys [feistelRounds]uint64
+}
+
+// mix64 is the finalizer of SplitMix64.
+// See https://prng.di.unimi.it/splitmix64.c
+func mix64(z uint64) uint64 {
+	z ^= z >> 30
+	z *= 0xbf58476d1ce4e5b9
+	z ^= z >> 27
+	z *= 0x94d049bb133111eb
+	z ^= z >> 31
+	return z
+}
+
+// newFeistel returns a network permuting the values in [0, 2^w), for the smallest even w such that
+// `limit-1` fits in w bits, with round keys derived from `seed`.
+func newFeistel(limit *big.Int, seed uint64) feistel {
+	w := uint(new(big.Int).Sub(limit, bigOne).BitLen())
+	f := feistel{half: max(1, (w+1)/2)}
+	for i := range f.keys {
+		f.keys[i] = mix64(seed + uint64(i+1)*0x9e3779b97f4a7c15)
+	}
+	return f
+}
+
+// small reports whether the network operates on 64-bit words.
+func (f *feistel) small() bool {
+	return f.half <= 32
+}
+
+// permute64 returns the image of `x`.
+// Precondition: `f.small()`.
+func (f *feistel) permute64(x uint64) uint64 {
+	mask := uint64(1)<<f.half - 1
+	l, r := x>>f.half, x&mask
+	for _, key := range f.keys {
+		l, r = r, l^(mix64(r^key)&mask)
+	}
+	return l<<f.half | r
+}
+
+// permuteBig returns the image of `x`, storing it in `x`.
+func (f *feistel) permuteBig(x *big.Int) *big.Int {
+	size := (f.half + 7) / 8
+	mask := new(big.Int).Sub(new(big.Int).Lsh(bigOne, f.half), bigOne)
+	l, r := new(big.Int).Rsh(x, f.half), new(big.Int).And(x, mask)
+	in := make([]byte, 16+size)
+	out := make([]byte, 0, (size+sha256.Size-1)/sha256.Size*sha256.Size)
+	var v big.Int
+	for _, key := range f.keys {
+		// round function: SHA-256 in counter mode over the key and the right half
+		binary.BigEndian.PutUint64(in, key)
+		r.FillBytes(in[16:])
+		out = out[:0]
+		for block := uint64(0); uint(len(out)) < size; block++ {
+			binary.BigEndian.PutUint64(in[8:], block)
+			sum := sha256.Sum256(in)
+			out = append(out, sum[:]...)
+		}
+		v.SetBytes(out[:size]).And(&v, mask)
+		l.Xor(l, &v)
+		l, r = r, l
+	}
+	return x.Lsh(l, f.half).Or(x, r)
+}
+
+// Shuffle enumerates all the combinations of k out of n elements exactly once, in a pseudo-random order
+// determined by a seed. The i-th combination visited (its counter) is the combination at rank `π(i)` in
+// Cool-lex order, where π is a keyed bijection over [0, C(n,k)): a Feistel network over the smallest
+// even number of bits to hold the ranks, restricted to [0, C(n,k)) by cycle walking.
+//
+// Because the order is a function of the counter, the enumeration can be resumed from a counter, see Seek,
+// and split into shards of consecutive counters, see Shard.
+type Shuffle struct {
+	n, k     uint
+	count    *big.Int // C(n,k)
+	network  feistel
+	counter  *big.Int // of the next combination
+	end      *big.Int // of the shard
+	elements []uint
+}
+
+// Rank returns the rank, in Cool-lex order, of the combination visited at position `counter`.
+//
+// It is an error to pass `counter` out of range [0, C(n,k)).
+func (shuffle *Shuffle) Rank(counter *big.Int) (*big.Int, error) {
+	if counter.Sign() < 0 || counter.Cmp(shuffle.count) >= 0 {
+		return nil, fmt.Errorf("counter %v out of range [0, %v)", counter, shuffle.count)
+	}
+	if shuffle.network.small() {
+		return new(big.Int).SetUint64(shuffle.rank64(counter.Uint64())), nil
+	}
+	return shuffle.rankBig(new(big.Int).Set(counter)), nil
+}
+
+// rank64 returns the rank visited at `counter`, by cycle walking.
+// Precondition: `shuffle.network.small()`.
+func (shuffle *Shuffle) rank64(counter uint64) uint64 {
+	count := shuffle.count.Uint64() // C(n,k)<=2^64-1 as the ranks fit in 64 bits
+	r := shuffle.network.permute64(counter)
+	for r >= count {
+		r = shuffle.network.permute64(r)
+	}
+	return r
+}
+
+// rankBig returns the rank visited at `counter`, by cycle walking, storing it in `counter`.
+func (shuffle *Shuffle) rankBig(counter *big.Int) *big.Int {
+	r := shuffle.network.permuteBig(counter)
+	for r.Cmp(shuffle.count) >= 0 {
+		r = shuffle.network.permuteBig(r)
+	}
+	return r
+}
+
+// Counter returns the position, in the shuffled order, of the next combination to visit.
+func (shuffle *Shuffle) Counter() *big.Int {
+	return new(big.Int).Set(shuffle.counter)
+}
+
+// Seek sets the position, in the shuffled order, of the next combination to visit, for resumption.
+//
+// It is an error to pass `counter` out of range [0, end], where `end` is C(n,k) or the end of the shard.
+func (shuffle *Shuffle) Seek(counter *big.Int) error {
+	if counter.Sign() < 0 || counter.Cmp(shuffle.end) > 0 {
+		return fmt.Errorf("counter %v out of range [0, %v]", counter, shuffle.end)
+	}
+	shuffle.counter.Set(counter)
+	return nil
+}
+
+// Shard restricts the enumeration to the `index`-th of `shards` contiguous ranges of counters of (nearly)
+// equal size, and seeks to the start of the range. The shards of a seed partition the combinations.
+//
+// It is an error to pass `shards=0` or `index>=shards`.
+func (shuffle *Shuffle) Shard(index, shards uint) error {
+	if index >= shards {
+		return fmt.Errorf("shard %d out of range [0, %d)", index, shards)
+	}
+	bound := func(i uint) *big.Int {
+		b := new(big.Int).Mul(shuffle.count, new(big.Int).SetUint64(uint64(i)))
+		return b.Quo(b, new(big.Int).SetUint64(uint64(shards)))
+	}
+	shuffle.counter, shuffle.end = bound(index), bound(index+1)
+	return nil
+}
+
+// Slices returns an iterator over the remaining combinations, each represented as a slice of k elements in
+// ascending order.
+//
+// Note: the slice is reused between iterations and should only be used for reading.
+func (shuffle *Shuffle) Slices() iter.Seq[[]uint] {
+	return func(yield func([]uint) bool) {
+		if shuffle.network.small() && shuffle.count.BitLen() <= bits.UintSize {
+			counter, end := shuffle.counter.Uint64(), shuffle.end.Uint64()
+			for ; counter < end; counter++ {
+				// no error for C(n,k) that fits in a uint, see numComb
+				shuffle.elements, _ = unrank(uint(shuffle.rank64(counter)), shuffle.n, shuffle.k, shuffle.elements)
+				if !yield(shuffle.elements) {
+					break
+				}
+			}
+			shuffle.counter.SetUint64(counter)
+			return
+		}
+		for ; shuffle.counter.Cmp(shuffle.end) < 0; shuffle.counter.Add(shuffle.counter, bigOne) {
+			r, _ := shuffle.Rank(shuffle.counter)
+			shuffle.elements, _ = UnrankBig(r, shuffle.n, shuffle.k)
+			if !yield(shuffle.elements) {
+				return
+			}
+		}
+	}
+}
+
+// Combinations returns an iterator over the remaining combinations.
+func (shuffle *Shuffle) Combinations() Combinations {
+	return func(yield func(Elements) bool) {
+		for elements := range shuffle.Slices() {
+			if !yield(slices.Values(elements)) {
+				return
+			}
+		}
+	}
+}
+
+// NewShuffle returns a generator that yields every combination of k out of n elements exactly once, in a
+// pseudo-random order determined by `seed`. Like the other generators, it yields no combinations for k=0.
+//
+// n: number of elements to combine; n>=k must hold.
+//
+// k: number of elements in each combination.
+//
+// It is an error to pass arguments such that n < k.
+func NewShuffle(n, k uint, seed uint64) (Shuffle, error) {
+	if n < k {
+		return Shuffle{}, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	count := new(big.Int)
+	if k > 0 {
+		count = binomial(n, k)
+	}
+	shuffle := Shuffle{
+		n:       n,
+		k:       k,
+		count:   count,
+		network: newFeistel(count, seed),
+		counter: new(big.Int),
+		end:     new(big.Int).Set(count),
+	}
+	return shuffle, nil
+}
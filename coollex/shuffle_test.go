@@ -0,0 +1,185 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+// collectRanks returns the ranks of the combinations yielded by `shuffle`
+func collectRanks(t *testing.T, shuffle *Shuffle, n uint) []uint {
+	t.Helper()
+	var ranks []uint
+	for elements := range shuffle.Slices() {
+		r, err := Rank(elements, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ranks = append(ranks, r)
+	}
+	return ranks
+}
+
+func TestShuffleBijection(t *testing.T) {
+	testCases := []struct{ n, k uint }{
+		{1, 1}, {2, 1}, {5, 2}, {9, 9}, {12, 5}, {15, 7}, {20, 3},
+	}
+	for _, tc := range testCases {
+		for seed := range uint64(3) {
+			shuffle, _ := NewShuffle(tc.n, tc.k, seed)
+			ranks := collectRanks(t, &shuffle, tc.n)
+			count := binomial(tc.n, tc.k).Uint64()
+			if uint64(len(ranks)) != count {
+				t.Fatalf("number of combinations: expected %d, got %d, for n %d and k %d", count, len(ranks), tc.n, tc.k)
+			}
+			slices.Sort(ranks)
+			for i, r := range ranks {
+				if uint(i) != r {
+					t.Fatalf("rank %d: visited %d, for n %d, k %d and seed %d", i, r, tc.n, tc.k, seed)
+				}
+			}
+		}
+	}
+}
+
+func TestShuffleSeed(t *testing.T) {
+	a, _ := NewShuffle(15, 7, 42)
+	b, _ := NewShuffle(15, 7, 42)
+	c, _ := NewShuffle(15, 7, 43)
+	ranksA, ranksB, ranksC := collectRanks(t, &a, 15), collectRanks(t, &b, 15), collectRanks(t, &c, 15)
+	if !slices.Equal(ranksA, ranksB) {
+		t.Fatal("the order is expected to be the same for the same seed")
+	}
+	if slices.Equal(ranksA, ranksC) {
+		t.Fatal("the order is expected to differ for different seeds")
+	}
+	if slices.IsSorted(ranksA) {
+		t.Fatal("the order is expected to be shuffled")
+	}
+}
+
+func TestShuffleShardAndSeek(t *testing.T) {
+	const n, k, seed = 14, 6, 7
+	whole, _ := NewShuffle(n, k, seed)
+	expect := collectRanks(t, &whole, n)
+
+	var actual []uint
+	for i := range uint(5) {
+		shard, _ := NewShuffle(n, k, seed)
+		if err := shard.Shard(i, 5); err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, collectRanks(t, &shard, n)...)
+	}
+	if !slices.Equal(expect, actual) {
+		t.Fatal("the shards are expected to partition the enumeration, in order")
+	}
+
+	// stop, then resume from the counter in a new generator
+	shuffle, _ := NewShuffle(n, k, seed)
+	count := 0
+	for range shuffle.Combinations() {
+		if count++; count == 100 {
+			break
+		}
+	}
+	counter := shuffle.Counter()
+	if counter.Cmp(big.NewInt(99)) != 0 {
+		t.Fatalf("counter: expected 99, got %v", counter)
+	}
+	resumed, _ := NewShuffle(n, k, seed)
+	if err := resumed.Seek(counter); err != nil {
+		t.Fatal(err)
+	}
+	if actual := collectRanks(t, &resumed, n); !slices.Equal(expect[99:], actual) {
+		t.Fatal("the resumed enumeration is expected to continue at the counter")
+	}
+	if actual := collectRanks(t, &shuffle, n); !slices.Equal(expect[99:], actual) {
+		t.Fatal("the enumeration is expected to resume at the combination for which yield returned false")
+	}
+}
+
+func TestShuffleBig(t *testing.T) {
+	const n, k = 300, 150
+	shuffle, _ := NewShuffle(n, k, 1)
+	count := binomial(n, k)
+	if err := shuffle.Seek(new(big.Int).Sub(count, big.NewInt(20))); err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]bool{}
+	visited := 0
+	for elements := range shuffle.Slices() {
+		r, err := RankBig(elements, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[r.String()] {
+			t.Fatalf("rank %v visited twice", r)
+		}
+		seen[r.String()] = true
+		visited++
+	}
+	if visited != 20 {
+		t.Fatalf("number of combinations: expected 20, got %d", visited)
+	}
+	// the network is a bijection over the bits of the ranks
+	f := newFeistel(big.NewInt(1<<12), 5) // the big path, on a domain that can be enumerated in full
+	images := map[string]bool{}
+	for x := range int64(1 << 12) {
+		images[f.permuteBig(big.NewInt(x)).String()] = true
+	}
+	if len(images) != 1<<12 {
+		t.Fatalf("number of images: expected %d, got %d", 1<<12, len(images))
+	}
+}
+
+func TestShuffleLargestUint(t *testing.T) {
+	// C(66,33) fits in 64 bits, although intermediate products of simplemath.NumComb overflow
+	const n, k = 66, 33
+	shuffle, _ := NewShuffle(n, k, 1)
+	visited := 0
+	for elements := range shuffle.Slices() {
+		r, err := RankBig(elements, n)
+		if err != nil {
+			t.Fatalf("combination %v: %v", elements, err)
+		}
+		expect, _ := shuffle.Rank(big.NewInt(int64(visited)))
+		if r.Cmp(expect) != 0 {
+			t.Fatalf("counter %d: expected rank %v, got %v", visited, expect, r)
+		}
+		if visited++; visited == 100 {
+			break
+		}
+	}
+}
+
+func TestShuffleErrors(t *testing.T) {
+	if _, err := NewShuffle(2, 3, 0); err == nil {
+		t.Fatal("error is expected for n<k")
+	}
+	shuffle, _ := NewShuffle(5, 2, 0)
+	if err := shuffle.Shard(2, 2); err == nil {
+		t.Fatal("error is expected for out-of-range shard")
+	}
+	if err := shuffle.Seek(big.NewInt(11)); err == nil {
+		t.Fatal("error is expected for out-of-range counter")
+	}
+	if _, err := shuffle.Rank(big.NewInt(10)); err == nil {
+		t.Fatal("error is expected for out-of-range counter")
+	}
+	empty, _ := NewShuffle(5, 0, 0)
+	for range empty.Combinations() {
+		t.Fatal("no combinations are expected for k=0")
+	}
+}
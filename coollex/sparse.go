@@ -0,0 +1,197 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// run is a maximal sequence of consecutive selected elements
+type run struct {
+	start, length uint
+}
+
+// Sparse generates combinations in Cool-lex order, storing only the selected elements, as runs of
+// consecutive elements. Its state takes O(k) space regardless of `n`, a successor is computed in O(1)
+// (looplessly), and the elements of a combination are yielded in O(k).
+//
+// It is suitable for enumerating combinations of few out of very many elements, for example `n=1000000`
+// and `k=2`.
+type Sparse struct {
+	n     uint
+	runs  []run // runs of selected elements; the run of the least elements is the last one
+	ended bool
+}
+
+// hasNext reports whether more combinations are available
+func (sparse *Sparse) hasNext() bool {
+	return !sparse.ended
+}
+
+// next advances to the next combination in cool-lex order.
+//
+// In terms of the selected (1) and the other (0) elements, the successor rotates, by one position, the
+// shortest prefix that ends with 010 or 011; or the whole string, when there is no such prefix. Hence,
+// at most the two least runs change and at most one run is added or merged.
+func (sparse *Sparse) next() {
+	runs := sparse.runs
+	last := len(runs) - 1
+	r1 := &runs[last]
+
+	if r1.start > 0 { // 0^q 1 ...
+		j := r1.start
+		switch {
+		case r1.length > 1: // 0^q 1 1 -> 1 0^q 1
+			r1.start++
+			r1.length--
+			sparse.runs = append(runs, run{0, 1})
+		case j+1 == sparse.n:
+			sparse.ended = true
+		default: // 0^q 1 0 -> 0 0^q 1
+			r1.start++
+			sparse.merge(last)
+		}
+		return
+	}
+
+	if last == 0 { // 1^p 0 -> 0 1^p
+		if r1.length == sparse.n {
+			sparse.ended = true
+			return
+		}
+		r1.start = 1
+		return
+	}
+
+	r2 := &runs[last-1]
+	j := r2.start
+	switch {
+	case r2.length > 1: // 1^p 0^q 1 1 -> 1 1^p 0^q 1
+		r1.length++
+		r2.start++
+		r2.length--
+	case j+1 == sparse.n:
+		sparse.ended = true
+	default: // 1^p 0^q 1 0 -> 0 1^p 0^q 1
+		r1.start = 1
+		r2.start++
+		sparse.merge(last - 1)
+	}
+}
+
+// merge merges the run at index i into the following (greater) run, if they are adjacent.
+func (sparse *Sparse) merge(i int) {
+	runs := sparse.runs
+	if i == 0 || runs[i].start+runs[i].length != runs[i-1].start {
+		return
+	}
+	runs[i-1].start = runs[i].start
+	runs[i-1].length += runs[i].length
+	sparse.runs = append(runs[:i], runs[i+1:]...)
+}
+
+// newSparse initializes the algorithm for the specified number of 0-bits (s) and number of 1-bits (t).
+// Precondition: `t>0`.
+func newSparse(s, t uint) Sparse {
+	runs := make([]run, 1, min(t, s+1))
+	runs[0] = run{0, t}
+	return Sparse{n: s + t, runs: runs}
+}
+
+// Elements returns an iterator over the elements selected for the current combination.
+func (sparse *Sparse) Elements() Elements {
+	return func(yield func(uint) bool) {
+		for i := len(sparse.runs) - 1; i >= 0; i-- {
+			r := sparse.runs[i]
+			for element := r.start; element < r.start+r.length; element++ {
+				if !yield(element) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Combinations returns an iterator over the generated combinations.
+func (sparse *Sparse) Combinations() Combinations {
+	return func(yield func(Elements) bool) {
+		for sparse.hasNext() && yield(sparse.Elements()) {
+			sparse.next()
+		}
+	}
+}
+
+// NewSparse returns a combinations generator that yields combinations in Cool-lex order, storing internally
+// just the selected elements.
+//
+// n: number of elements to combine; n>=k must hold.
+//
+// k: number of elements in each combination.
+//
+// It is an error to pass arguments such that n < k.
+func NewSparse(n, k uint) (Sparse, error) {
+	if n < k {
+		return Sparse{}, fmt.Errorf("n (%d) less than k (%d)", n, k)
+	}
+	if k == 0 {
+		return Sparse{ended: true}, nil
+	}
+	return newSparse(n-k, k), nil
+}
+
+func (sparse *Sparse) current() bool {
+	return sparse.hasNext()
+}
+
+func (sparse *Sparse) step() {
+	sparse.next()
+}
+
+func (sparse *Sparse) size() uint {
+	return sparse.n
+}
+
+func (sparse *Sparse) reposition(elements []uint) {
+	runs := sparse.runs[:0]
+	for i := len(elements) - 1; i >= 0; i-- {
+		if last := len(runs) - 1; last >= 0 && runs[last].start == elements[i]+1 {
+			runs[last].start--
+			runs[last].length++
+		} else {
+			runs = append(runs, run{elements[i], 1})
+		}
+	}
+	sparse.runs = runs
+}
+
+func (sparse *Sparse) end() {
+	sparse.ended = true
+}
+
+// Advance skips `m` combinations, see ComputerWord64.Advance.
+func (sparse *Sparse) Advance(m uint64) {
+	advance(sparse, m)
+}
+
+// AdvanceBig skips `m` combinations, see ComputerWord64.Advance.
+//
+// It is an error to pass a negative `m`.
+func (sparse *Sparse) AdvanceBig(m *big.Int) error {
+	return advanceBig(sparse, m)
+}
+
+// Stride returns an iterator over every m-th generated combination, see ComputerWord64.Stride.
+func (sparse *Sparse) Stride(m uint64) Combinations {
+	return stride(sparse, m)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import "testing"
+
+// `n` and `k` for benchmarking the enumeration of few out of many elements
+const (
+	benchSparseN = 1000
+	benchSparseK = 2
+)
+
+func BenchmarkSparse(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		sparse, _ := NewSparse(benchAlgorithmN, benchAlgorithmK)
+		for sparse.hasNext() {
+			sparse.next()
+		}
+	}
+}
+
+func BenchmarkSparseElements(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		sparse, _ := NewSparse(benchSparseN, benchSparseK)
+		for combination := range sparse.Combinations() {
+			for e := range combination {
+				_ = e
+			}
+		}
+	}
+}
+
+func BenchmarkComputerWordBigElements(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		word, _ := NewComputerWordBig(benchSparseN, benchSparseK)
+		for combination := range word.Combinations() {
+			for e := range combination {
+				_ = e
+			}
+		}
+	}
+}
+
+func TestSparse(t *testing.T) {
+	newSparse := func(n, k uint) (coollexAlgorithm, error) {
+		sparse, err := NewSparse(n, k)
+		return &sparse, err
+	}
+	testCoollex(t, newSparse)
+	testOrder(t, newSparse, largeCases...)
+	if _, err := NewSparse(2, 3); err == nil {
+		t.Fatal("error is expected for n<k")
+	}
+}
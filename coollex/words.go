@@ -0,0 +1,263 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coollex
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/dastoikov/cool-lex-go/v2/internal/bitword"
+)
+
+// The functions in this file step through the combinations as words, without a generator. A word represents a
+// combination of k out of n elements as in the `Words()` iterators: the bit i is set for a selected element i.
+//
+// Each successor rotates a prefix of the word, of length m, by one position: the bit m-1 moves to position 0,
+// and the bits [0, m-1) move up by one position. The prefix is the shortest one ending in the bits 0, 1, x
+// (position 0 first), or the whole word if there is none. The predecessor rotates the same prefix backwards.
+// Rotating the whole last word yields the first word, and conversely; the functions signal the end of the
+// sequence by returning false, along with the first (Next) or the last (Prev) word, so that a caller can also
+// cycle through the combinations.
+//
+// The functions do not validate their arguments: the words must be combinations of k out of n elements.
+
+// FirstWord64 returns the first combination of k out of n elements, in Cool-lex order: the bits [0, k) set.
+// Precondition: `k<=n<64`.
+func FirstWord64(n, k uint) int64 {
+	return 1<<k - 1
+}
+
+// LastWord64 returns the last combination of k out of n elements, in Cool-lex order: the bits [0, k-1) and the
+// bit n-1 set; 0 for k=0.
+// Precondition: `k<=n<64`.
+func LastWord64(n, k uint) int64 {
+	if k == 0 {
+		return 0
+	}
+	return 1<<(k-1) - 1 | 1<<(n-1)
+}
+
+// NextWord64 returns the combination that follows `w`, of k out of n elements, in Cool-lex order, and true;
+// or, if `w` is the last combination, the first combination and false.
+// Precondition: `w` is a combination of k out of n elements, and `n<64`.
+func NextWord64(w int64, n uint) (int64, bool) {
+	if w == 0 { // k=0: the only combination, the empty one, is the last one
+		return 0, false
+	}
+	// see ComputerWord64.next
+	r0 := w & (w + 1)
+	r1 := r0 ^ (r0 - 1)
+	r0 = r1 + 1
+	r1 = r1 & w
+	if r0&w > 0 {
+		r0 -= 1
+	} else {
+		r0 = 0
+	}
+	next := w + r1 - r0
+	if next&(1<<n) != 0 {
+		return FirstWord64(n, uint(bits.OnesCount64(uint64(w)))), false
+	}
+	return next, true
+}
+
+// PrevWord64 returns the combination that precedes `w`, of k out of n elements, in Cool-lex order, and true;
+// or, if `w` is the first combination, the last combination and false.
+// Precondition: `w` is a combination of k out of n elements, and `n<64`.
+func PrevWord64(w int64, n uint) (int64, bool) {
+	v := uint64(w)
+	if v&(v+1) == 0 { // the first combination: the bits [0, k) set
+		return LastWord64(n, uint(bits.OnesCount64(v))), false
+	}
+	// the positions i of the bits 0, 1, at i and i+1; for a word starting with 0, 1, the following one
+	rises := ^v & (v >> 1)
+	if v&3 == 2 {
+		rises &^= 1
+	}
+	m := n
+	if rises != 0 {
+		m = uint(bits.TrailingZeros64(rises)) + 2
+	}
+	mask := uint64(1)<<m - 1
+	return int64(v&^mask | v>>1&(mask>>1) | (v&1)<<(m-1)), true
+}
+
+// FirstWord32 returns the first combination of k out of n elements, in Cool-lex order, see FirstWord64.
+// Precondition: `k<=n<32`.
+func FirstWord32(n, k uint) int32 {
+	return 1<<k - 1
+}
+
+// LastWord32 returns the last combination of k out of n elements, in Cool-lex order, see LastWord64.
+// Precondition: `k<=n<32`.
+func LastWord32(n, k uint) int32 {
+	return int32(LastWord64(n, k))
+}
+
+// NextWord32 returns the combination that follows `w`, see NextWord64.
+// Precondition: `w` is a combination of k out of n elements, and `n<32`.
+func NextWord32(w int32, n uint) (int32, bool) {
+	if w == 0 { // k=0, see NextWord64
+		return 0, false
+	}
+	// see ComputerWord32.next
+	r0 := w & (w + 1)
+	r1 := r0 ^ (r0 - 1)
+	r0 = r1 + 1
+	r1 = r1 & w
+	if r0&w > 0 {
+		r0 -= 1
+	} else {
+		r0 = 0
+	}
+	next := w + r1 - r0
+	if next&(1<<n) != 0 {
+		return FirstWord32(n, uint(bits.OnesCount32(uint32(w)))), false
+	}
+	return next, true
+}
+
+// PrevWord32 returns the combination that precedes `w`, see PrevWord64.
+// Precondition: `w` is a combination of k out of n elements, and `n<32`.
+func PrevWord32(w int32, n uint) (int32, bool) {
+	prev, ok := PrevWord64(int64(w), n)
+	return int32(prev), ok
+}
+
+// FirstWordBig sets `dst` to the first combination of k out of n elements, in Cool-lex order, see FirstWord64,
+// and returns `dst`.
+// Precondition: `k<=n`.
+func FirstWordBig(dst *big.Int, n, k uint) *big.Int {
+	dst.SetUint64(0)
+	for i := range k {
+		dst.SetBit(dst, int(i), 1)
+	}
+	return dst
+}
+
+// LastWordBig sets `dst` to the last combination of k out of n elements, in Cool-lex order, see LastWord64,
+// and returns `dst`.
+// Precondition: `k<=n`.
+func LastWordBig(dst *big.Int, n, k uint) *big.Int {
+	dst.SetUint64(0)
+	if k == 0 {
+		return dst
+	}
+	for i := range k - 1 {
+		dst.SetBit(dst, int(i), 1)
+	}
+	return dst.SetBit(dst, int(n-1), 1)
+}
+
+// limbsOf sets `dst` to `w` and returns its limbs, of at least enough words for n bits
+func limbsOf(dst, w *big.Int, n uint) []big.Word {
+	dst.Set(w)
+	limbs := dst.Bits()
+	for size := int((n + bits.UintSize - 1) / bits.UintSize); len(limbs) < size; {
+		limbs = append(limbs, 0)
+	}
+	return limbs
+}
+
+// firstRise returns the lowest position i>=from such that the bit i is 0 and the bit i+1 is 1, and true; or
+// false if there is none
+func firstRise(limbs []big.Word, from uint) (uint, bool) {
+	for i := int(from / bits.UintSize); i < len(limbs); i++ {
+		next := big.Word(0)
+		if i+1 < len(limbs) {
+			next = limbs[i+1]
+		}
+		rises := ^limbs[i] & (limbs[i]>>1 | next<<(bits.UintSize-1))
+		if i == int(from/bits.UintSize) {
+			rises &^= 1<<(from%bits.UintSize) - 1
+		}
+		if rises != 0 {
+			return uint(i*bits.UintSize + bits.TrailingZeros(uint(rises))), true
+		}
+	}
+	return 0, false
+}
+
+// rotateLeft rotates the bits [0, m) by one position: the bit 0 moves to m-1.
+// Precondition: `m>=1`.
+func rotateLeft(limbs []big.Word, m uint) {
+	last, b := int((m-1)/bits.UintSize), (m-1)%bits.UintSize
+	bottom := limbs[0] & 1
+	for i := range last {
+		limbs[i] = limbs[i]>>1 | limbs[i+1]<<(bits.UintSize-1)
+	}
+	mask := ^big.Word(0) >> (bits.UintSize - 1 - b)
+	limbs[last] = limbs[last]&^mask | limbs[last]>>1&(mask>>1) | bottom<<b
+}
+
+// NextWordBig sets `dst` to the combination that follows `w`, of k out of n elements, in Cool-lex order, and
+// returns true; or, if `w` is the last combination, sets `dst` to the first combination and returns false.
+// `dst` and `w` may be the same.
+// Precondition: `w` is a combination of k out of n elements.
+func NextWordBig(dst, w *big.Int, n uint) bool {
+	limbs := limbsOf(dst, w, n)
+	m := n
+	if i, ok := firstRise(limbs, 0); ok && i+3 <= n {
+		m = i + 3
+	}
+	if m == 0 {
+		return false
+	}
+	bitword.RotatePrefix(limbs, m)
+	dst.SetBits(limbs)
+	return !isFirst(limbs) // only the last combination is followed by the first one
+}
+
+// isFirst reports whether the word is the first combination: the bits [0, k) set
+func isFirst(limbs []big.Word) bool {
+	i := 0
+	for i < len(limbs) && limbs[i] == ^big.Word(0) {
+		i++
+	}
+	if i == len(limbs) {
+		return true
+	}
+	w := uint(limbs[i])
+	if w&(w+1) != 0 {
+		return false
+	}
+	for _, l := range limbs[i+1:] {
+		if l != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// PrevWordBig sets `dst` to the combination that precedes `w`, of k out of n elements, in Cool-lex order, and
+// returns true; or, if `w` is the first combination, sets `dst` to the last combination and returns false.
+// `dst` and `w` may be the same.
+// Precondition: `w` is a combination of k out of n elements.
+func PrevWordBig(dst, w *big.Int, n uint) bool {
+	limbs := limbsOf(dst, w, n)
+	if n == 0 {
+		return false
+	}
+	first := isFirst(limbs)
+	from := uint(0)
+	if limbs[0]&3 == 2 {
+		from = 1
+	}
+	m := n
+	if i, ok := firstRise(limbs, from); ok {
+		m = i + 2
+	}
+	rotateLeft(limbs, m)
+	dst.SetBits(limbs)
+	return !first
+}
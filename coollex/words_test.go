@@ -0,0 +1,129 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package coollex
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestWord64Steps(t *testing.T) {
+	for _, n := range []uint{1, 2, 5, 9, 12, 63} {
+		for k := uint(1); k <= n; k++ {
+			if n == 63 && k > 2 {
+				break
+			}
+			word, _ := NewComputerWord64(n, k)
+			expect := slices.Collect(word.Words())
+			first, last := FirstWord64(n, k), LastWord64(n, k)
+			if first != expect[0] || last != expect[len(expect)-1] {
+				t.Fatalf("n=%d, k=%d: expected first %#x and last %#x, got %#x and %#x",
+					n, k, expect[0], expect[len(expect)-1], first, last)
+			}
+			w := first
+			for i := 1; i < len(expect); i++ {
+				next, ok := NextWord64(w, n)
+				if !ok || next != expect[i] {
+					t.Fatalf("n=%d, k=%d: expected %#x to follow %#x, got %#x, %v", n, k, expect[i], w, next, ok)
+				}
+				if prev, ok := PrevWord64(next, n); !ok || prev != w {
+					t.Fatalf("n=%d, k=%d: expected %#x to precede %#x, got %#x, %v", n, k, w, next, prev, ok)
+				}
+				w = next
+			}
+			if next, ok := NextWord64(last, n); ok || next != first {
+				t.Fatalf("n=%d, k=%d: expected the end, and the first combination, got %#x, %v", n, k, next, ok)
+			}
+			if prev, ok := PrevWord64(first, n); ok || prev != last {
+				t.Fatalf("n=%d, k=%d: expected the end, and the last combination, got %#x, %v", n, k, prev, ok)
+			}
+		}
+	}
+}
+
+func TestWord32Steps(t *testing.T) {
+	const n, k = 31, 3
+	word, _ := NewComputerWord32(n, k)
+	expect := slices.Collect(word.Words())
+	var actual []int32
+	for w, ok := FirstWord32(n, k), true; ok; w, ok = NextWord32(w, n) {
+		actual = append(actual, w)
+	}
+	if !slices.Equal(actual, expect) {
+		t.Fatalf("expected %d combinations, got %d", len(expect), len(actual))
+	}
+	actual = actual[:0]
+	for w, ok := LastWord32(n, k), true; ok; w, ok = PrevWord32(w, n) {
+		actual = append(actual, w)
+	}
+	slices.Reverse(actual)
+	if !slices.Equal(actual, expect) {
+		t.Fatalf("expected %d combinations in reverse, got %d", len(expect), len(actual))
+	}
+}
+
+func TestWordBigSteps(t *testing.T) {
+	for _, tc := range []struct{ n, k uint }{{1, 1}, {6, 3}, {64, 1}, {65, 2}, {70, 68}, {130, 2}, {130, 129}} {
+		word, _ := NewComputerWordBig(tc.n, tc.k)
+		var expect []*big.Int
+		for w := range word.Words() {
+			expect = append(expect, new(big.Int).Set(w))
+		}
+		first, last := FirstWordBig(new(big.Int), tc.n, tc.k), LastWordBig(new(big.Int), tc.n, tc.k)
+		if first.Cmp(expect[0]) != 0 || last.Cmp(expect[len(expect)-1]) != 0 {
+			t.Fatalf("n=%d, k=%d: expected first %#x and last %#x, got %#x and %#x",
+				tc.n, tc.k, expect[0], expect[len(expect)-1], first, last)
+		}
+
+		// in place
+		w, i := new(big.Int).Set(first), 0
+		for ok := true; ok; ok = NextWordBig(w, w, tc.n) {
+			if i >= len(expect) || w.Cmp(expect[i]) != 0 {
+				t.Fatalf("n=%d, k=%d: unexpected %#x at %d", tc.n, tc.k, w, i)
+			}
+			i++
+		}
+		if i != len(expect) || w.Cmp(first) != 0 {
+			t.Fatalf("n=%d, k=%d: expected %d combinations, and the first one at the end, got %d, %#x",
+				tc.n, tc.k, len(expect), i, w)
+		}
+
+		// into another destination
+		prev := new(big.Int)
+		for i := len(expect) - 1; i > 0; i-- {
+			if !PrevWordBig(prev, expect[i], tc.n) || prev.Cmp(expect[i-1]) != 0 {
+				t.Fatalf("n=%d, k=%d: expected %#x to precede %#x, got %#x", tc.n, tc.k, expect[i-1], expect[i], prev)
+			}
+		}
+		if PrevWordBig(prev, first, tc.n) || prev.Cmp(last) != 0 {
+			t.Fatalf("n=%d, k=%d: expected the end, and the last combination, got %#x", tc.n, tc.k, prev)
+		}
+	}
+}
+
+func TestWordsNoElements(t *testing.T) {
+	// k=0: the empty combination is both the first and the last one
+	if w, ok := NextWord64(FirstWord64(5, 0), 5); ok || w != 0 {
+		t.Fatalf("NextWord64: expected 0 and false, got %#x and %t", w, ok)
+	}
+	if w, ok := NextWord32(FirstWord32(5, 0), 5); ok || w != 0 {
+		t.Fatalf("NextWord32: expected 0 and false, got %#x and %t", w, ok)
+	}
+	if w, ok := PrevWord64(0, 5); ok || w != 0 {
+		t.Fatalf("PrevWord64: expected 0 and false, got %#x and %t", w, ok)
+	}
+	w := new(big.Int)
+	if NextWordBig(w, FirstWordBig(w, 5, 0), 5) || w.Sign() != 0 {
+		t.Fatalf("NextWordBig: expected 0 and false, got %#x", w)
+	}
+}
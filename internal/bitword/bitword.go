@@ -0,0 +1,37 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bitword implements operations on the words that represent combinations, of arbitrary size, as
+// `big.Word` limbs, the least-significant first.
+package bitword
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// RotatePrefix rotates the bits [0, m) by one position: the bit m-1 moves to 0, and the bits [0, m-1) move up
+// by one position; that is, a successor step in Cool-lex order.
+// Precondition: `1<=m<=len(limbs)*bits.UintSize`.
+func RotatePrefix(limbs []big.Word, m uint) {
+	last, b := int((m-1)/bits.UintSize), (m-1)%bits.UintSize
+	top := limbs[last] >> b & 1
+	carry := big.Word(0)
+	for i := range last {
+		w := limbs[i]
+		limbs[i] = w<<1 | carry
+		carry = w >> (bits.UintSize - 1)
+	}
+	mask := ^big.Word(0) >> (bits.UintSize - 1 - b)
+	limbs[last] = limbs[last]&^mask | (limbs[last]<<1|carry)&mask
+	limbs[0] = limbs[0]&^1 | top
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The Cool-lex-Go Contributors, see the CONTRIBUTORS file.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file
+// except in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the
+// License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing permissions and
+// limitations under the License.
+package bitword
+
+import (
+	"math/big"
+	"math/bits"
+	"testing"
+)
+
+func TestRotatePrefix(t *testing.T) {
+	for _, m := range []uint{1, 2, 3, bits.UintSize - 1, bits.UintSize, bits.UintSize + 1, 2*bits.UintSize + 5} {
+		// a pattern over three limbs, with the bit m-1 set and the bit 0 clear
+		x := new(big.Int).Lsh(big.NewInt(0b1011_0110), 2*bits.UintSize)
+		x.SetBit(x, int(m-1), 1).SetBit(x, 0, 0).SetBit(x, bits.UintSize, 1)
+		limbs := make([]big.Word, 4)
+		copy(limbs, x.Bits())
+
+		mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), m), big.NewInt(1))
+		prefix := new(big.Int).And(x, mask)
+		expect := new(big.Int).AndNot(x, mask)
+		expect.Or(expect, new(big.Int).And(new(big.Int).Lsh(prefix, 1), mask))
+		expect.SetBit(expect, 0, prefix.Bit(int(m-1)))
+
+		RotatePrefix(limbs, m)
+		if actual := new(big.Int).SetBits(limbs); actual.Cmp(expect) != 0 {
+			t.Fatalf("m=%d: expected %#x, got %#x", m, expect, actual)
+		}
+	}
+}
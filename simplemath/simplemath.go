@@ -15,7 +15,7 @@ Package simplemath implements math operations used by the algorithms in the cool
 
 Additionally, it includes simple, naive implementations of math operations that are designed
 to facilitate writing tests. These functions return an error upon numeric overflow: Add, Mul, etc.
-They are used exclusively in tests and not in the Cool-lex algorithm implementations.
+They are used in tests and for counting and ranking, but not in the Cool-lex algorithm implementations.
 */
 package simplemath
 